@@ -0,0 +1,313 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/ironman-project/ironman/pkg/ironman"
+	"github.com/ironman-project/ironman/pkg/prompt"
+	"github.com/ironman-project/ironman/pkg/registry"
+	"github.com/ironman-project/ironman/pkg/template/model"
+	"github.com/ironman-project/ironman/pkg/template/values"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+const (
+	browseQuit = "q"
+	browseBack = "b"
+)
+
+type browseCmd struct {
+	in                io.Reader
+	out               io.Writer
+	client            *ironman.Ironman
+	prompter          *prompt.Prompter
+	registryURL       string
+	registry          registry.Registry
+	registryTemplates []*model.Template
+	registryLoaded    bool
+}
+
+func newBrowseCmd(client *ironman.Ironman, out io.Writer) *cobra.Command {
+	return newBrowseInputCmd(client, out, os.Stdin, nil)
+}
+
+//newBrowseInputCmd returns a browse command reading the user input from in, if the registry is nil
+//the registry is read from the --registry flag or the registry config
+func newBrowseInputCmd(client *ironman.Ironman, out io.Writer, in io.Reader, templatesRegistry registry.Registry) *cobra.Command {
+	browse := &browseCmd{
+		in:       in,
+		out:      out,
+		client:   client,
+		registry: templatesRegistry,
+	}
+
+	var browseCmd = &cobra.Command{
+		Use:   "browse",
+		Short: "Browses the installed and registry templates and guides the generation using a generator",
+		Long: `Browses the installed and registry templates and guides the generation using a generator.
+Type some text to fuzzy search the templates, the number of a template to see its details and generators,
+or 'q' to quit. Selecting a generator asks for the generation path and the values of the generator fields.
+Selecting a registry template offers to install it.
+
+The registry is a YAML document listing templates and their sources, it is read from the --registry URL
+or the registry key of the config file. e.g
+
+templates:
+  - id: simple-gohttp
+    name: Simple Go HTTP Template
+    description: A simple HTTP Go library template
+    source: https://github.com/ironman-project/simple-gohttp-template.git
+
+Example:
+ironman browse
+ironman browse --registry https://example.com/ironman/registry.yaml
+`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			browse.out = ensureIronmanOutput(browse.out)
+			browse.client = ensureIronmanClientWithProgress(browse.client, browse.out)
+			browse.prompter = prompt.New(browse.in, browse.out)
+			if browse.registry == nil {
+				registryURL := browse.registryURL
+				if registryURL == "" {
+					registryURL = viper.GetString("registry")
+				}
+				if registryURL != "" {
+					browse.registry = registry.New(registryURL)
+				}
+			}
+			err := browse.run()
+			if err == io.EOF {
+				return nil
+			}
+			return err
+		},
+	}
+
+	f := browseCmd.Flags()
+	f.StringVar(&browse.registryURL, "registry", "", "URL of a templates registry document to browse along with the installed templates")
+	return browseCmd
+}
+
+func (b *browseCmd) run() error {
+	query := ""
+	for {
+		templates, err := b.client.Search(query)
+		if err != nil {
+			return err
+		}
+
+		available, err := b.available(query)
+		if err != nil {
+			return err
+		}
+
+		fmt.Fprintln(b.out)
+		if query != "" {
+			fmt.Fprintf(b.out, "Templates matching '%s'\n", query)
+		} else {
+			fmt.Fprintln(b.out, "Installed templates")
+		}
+		b.printTemplates(templates, 0)
+
+		if b.registry != nil {
+			fmt.Fprintln(b.out, "Registry templates")
+			b.printTemplates(available, len(templates))
+		}
+
+		input, err := b.prompter.String("Search, select a number or q to quit", "")
+		if err != nil {
+			return err
+		}
+
+		if input == browseQuit {
+			return nil
+		}
+
+		if n, ok := selection(input, len(templates)+len(available)); ok {
+			if n < len(templates) {
+				err = b.browseTemplate(templates[n])
+			} else {
+				err = b.install(available[n-len(templates)])
+			}
+			if err != nil {
+				return err
+			}
+			continue
+		}
+
+		query = input
+	}
+}
+
+//printTemplates prints a numbered list of templates starting after offset
+func (b *browseCmd) printTemplates(templates []*model.Template, offset int) {
+	if len(templates) == 0 {
+		fmt.Fprintln(b.out, "None")
+	}
+
+	for n, template := range templates {
+		fmt.Fprintf(b.out, "%3d) %s - %s\n", offset+n+1, template.ID, template.Name)
+	}
+}
+
+//available returns the registry templates that are not installed matching the query. The registry is only read once,
+//if it can't be read the error is printed and only the installed templates are browsed
+func (b *browseCmd) available(query string) ([]*model.Template, error) {
+	if b.registry == nil {
+		return nil, nil
+	}
+
+	if !b.registryLoaded {
+		templates, err := b.registry.Templates()
+		if err != nil {
+			fmt.Fprintln(b.out, "failed to read the templates registry:", err)
+		}
+		b.registryTemplates = templates
+		b.registryLoaded = true
+	}
+
+	installed, err := b.client.Search("")
+	if err != nil {
+		return nil, err
+	}
+
+	var available []*model.Template
+	for _, template := range b.registryTemplates {
+		if !isInstalled(template, installed) {
+			available = append(available, template)
+		}
+	}
+	return ironman.FilterTemplates(available, query), nil
+}
+
+//install shows a registry template and installs it if the user confirms it
+func (b *browseCmd) install(template *model.Template) error {
+	fmt.Fprintln(b.out)
+	fmt.Fprintf(b.out, "%s - %s\n", template.ID, template.Name)
+	if template.Description != "" {
+		fmt.Fprintln(b.out, template.Description)
+	}
+	fmt.Fprintln(b.out, "Source:", template.Source)
+	fmt.Fprintln(b.out)
+
+	confirm, err := b.prompter.String(fmt.Sprintf("Install %s? (y/n)", template.ID), "n")
+	if err != nil {
+		return err
+	}
+
+	if strings.ToLower(confirm) != "y" {
+		fmt.Fprintln(b.out, "Install canceled")
+		return nil
+	}
+
+	fmt.Fprintln(b.out, "Installing template", template.Source, "...")
+	if err := b.client.Install(template.Source); err != nil {
+		fmt.Fprintln(b.out, errors.Wrapf(err, "failed to install %s", template.ID))
+		return nil
+	}
+	fmt.Fprintln(b.out, "Template", template.ID, "installed")
+	return nil
+}
+
+func (b *browseCmd) browseTemplate(template *model.Template) error {
+	for {
+		fmt.Fprintln(b.out)
+		if err := b.client.Describe(template.ID, ironman.FormatYAML, b.out); err != nil {
+			return err
+		}
+
+		fmt.Fprintln(b.out)
+		fmt.Fprintln(b.out, "Generators")
+		for n, generator := range template.Generators {
+			fmt.Fprintf(b.out, "%3d) %s - %s\n", n+1, generator.ID, generator.Description)
+		}
+
+		input, err := b.prompter.String("Select a generator number or b to go back", "")
+		if err != nil {
+			return err
+		}
+
+		if input == browseBack {
+			return nil
+		}
+
+		if n, ok := selection(input, len(template.Generators)); ok {
+			generated, err := b.generate(template, template.Generators[n])
+			if err != nil {
+				return err
+			}
+			if generated {
+				return nil
+			}
+			continue
+		}
+
+		fmt.Fprintf(b.out, "invalid selection '%s'\n", input)
+	}
+}
+
+//generate asks for the generation path and values and runs the generator, returns whether it generated anything.
+//A generation failure is printed instead of returned so the user can try again
+func (b *browseCmd) generate(template *model.Template, generator *model.Generator) (bool, error) {
+	resourceID := template.ID + ":" + generator.ID
+
+	fmt.Fprintln(b.out)
+	if err := b.client.Describe(resourceID, ironman.FormatYAML, b.out); err != nil {
+		return false, err
+	}
+	fmt.Fprintln(b.out)
+
+	path, err := b.prompter.String("Generation path", ".")
+	if err != nil {
+		return false, err
+	}
+
+	vals := values.Values{}
+	if err := b.prompter.Fields(generator.Fields, vals); err != nil {
+		return false, err
+	}
+
+	confirm, err := b.prompter.String(fmt.Sprintf("Generate %s on %s? (y/n)", resourceID, path), "n")
+	if err != nil {
+		return false, err
+	}
+
+	if strings.ToLower(confirm) != "y" {
+		fmt.Fprintln(b.out, "Generation canceled")
+		return false, nil
+	}
+
+	fmt.Fprintln(b.out, "Running template generator", generator.ID)
+	if err := b.client.Generate(context.Background(), template.ID, generator.ID, path, vals, false); err != nil {
+		fmt.Fprintln(b.out, errors.Wrapf(err, "failed to generate %s", resourceID))
+		return false, nil
+	}
+	fmt.Fprintln(b.out, "Done")
+	return true, nil
+}
+
+//selection returns the zero based index of a numbered list selection
+func selection(input string, length int) (int, bool) {
+	n, err := strconv.Atoi(input)
+	if err != nil || n < 1 || n > length {
+		return 0, false
+	}
+	return n - 1, true
+}
+
+//isInstalled returns whether a registry template is already installed
+func isInstalled(template *model.Template, installed []*model.Template) bool {
+	for _, t := range installed {
+		if t.ID == template.ID || (t.Source != "" && t.Source == template.Source) {
+			return true
+		}
+	}
+	return false
+}
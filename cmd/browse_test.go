@@ -0,0 +1,137 @@
+package cmd
+
+import (
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/spf13/cobra"
+
+	"github.com/ironman-project/ironman/pkg/ironman"
+	"github.com/ironman-project/ironman/pkg/registry"
+	"github.com/ironman-project/ironman/pkg/template/model"
+
+	testhelpers "github.com/ironman-project/ironman/cmd/testing"
+	"github.com/ironman-project/ironman/pkg/testutils"
+)
+
+//Links a local template for running browse tests
+func setUpBrowseCmd(t *testing.T, client *ironman.Ironman, testCase testhelpers.CmdTestCase) {
+	err := client.Link(testutils.FieldsTemplatePath(), "fields-template")
+	if err != nil {
+		t.Fatalf("failed to setUp browse tests %s", err)
+	}
+}
+
+//staticRegistry a registry listing a fixed list of templates
+type staticRegistry []*model.Template
+
+func (r staticRegistry) Templates() ([]*model.Template, error) {
+	return r, nil
+}
+
+func TestBrowseCmd(t *testing.T) {
+	tempGenerateDir := testutils.CreateTempDir("temp-browse", t)
+	defer func() {
+		_ = os.RemoveAll(tempGenerateDir)
+	}()
+	generationPath := filepath.Join(tempGenerateDir, "test-browse")
+	existingPath := filepath.Join(tempGenerateDir, "existing")
+	testutils.CreateDir(existingPath, t)
+	if err := ioutil.WriteFile(filepath.Join(existingPath, "file"), []byte("file"), 0644); err != nil {
+		t.Fatalf("failed to create existing generation path %s", err)
+	}
+
+	templatesRegistry := staticRegistry{
+		{ID: "fields-template", Name: "Fields Template", Source: "https://example.com/fields-template.git"},
+		{ID: "registry-template", Name: "Registry Template", Description: "A registry template", Source: "https://example.com/registry-template.git"},
+	}
+
+	tests := []struct {
+		testCase testhelpers.CmdTestCase
+		input    string
+		registry registry.Registry
+	}{
+		{
+			testhelpers.CmdTestCase{
+				Name:     "list installed templates",
+				Expected: "Installed templates\n  1) fields-template - Fields Template\n",
+			},
+			"q\n",
+			nil,
+		},
+		{
+			testhelpers.CmdTestCase{
+				Name:     "list registry templates that are not installed",
+				Expected: "Installed templates\n  1) fields-template - Fields Template\nRegistry templates\n  2) registry-template - Registry Template\n",
+			},
+			"q\n",
+			templatesRegistry,
+		},
+		{
+			testhelpers.CmdTestCase{
+				Name:     "cancel registry template install",
+				Expected: "registry-template - Registry Template\nA registry template\nSource: https://example.com/registry-template.git\n\nInstall registry-template? (y/n) [n]: Install canceled\n",
+			},
+			"2\nn\nq\n",
+			templatesRegistry,
+		},
+		{
+			testhelpers.CmdTestCase{
+				Name:     "search templates",
+				Expected: "Templates matching 'zzz'\nNone\n",
+			},
+			"zzz\nq\n",
+			nil,
+		},
+		{
+			testhelpers.CmdTestCase{
+				Name:     "end of input",
+				Expected: "Installed templates",
+			},
+			"",
+			nil,
+		},
+		{
+			testhelpers.CmdTestCase{
+				Name:     "guided generation",
+				Expected: "invalid value 'Bad Name' for field 'app.name': should match pattern ^[a-z]+$\n",
+			},
+			"1\n1\n" + generationPath + "\nBad Name\nmyapp\n\ny\nq\n",
+			nil,
+		},
+		{
+			testhelpers.CmdTestCase{
+				Name:     "failed generation goes back to the generators",
+				Expected: "failed to generate fields-template:app",
+			},
+			"1\n1\n" + existingPath + "\nmyapp\n\ny\nb\nq\n",
+			nil,
+		},
+		{
+			testhelpers.CmdTestCase{
+				Name:     "cancel generation",
+				Expected: "Generation canceled",
+			},
+			"1\n1\n" + filepath.Join(tempGenerateDir, "canceled") + "\nmyapp\n\nn\nb\nq\n",
+			nil,
+		},
+	}
+	for _, tt := range tests {
+		input, templatesRegistry := tt.input, tt.registry
+		testhelpers.RunCmdTests(t, []testhelpers.CmdTestCase{tt.testCase}, func(client *ironman.Ironman, out io.Writer) *cobra.Command {
+			return newBrowseInputCmd(client, out, strings.NewReader(input), templatesRegistry)
+		}, setUpBrowseCmd, nil)
+	}
+
+	if got := testutils.ReadFile(t, generationPath, "app.txt"); got != "myapp:8080\n" {
+		t.Errorf("expected generated app.txt %q got %q", "myapp:8080\n", got)
+	}
+
+	if testutils.FileExists(filepath.Join(tempGenerateDir, "canceled")) {
+		t.Errorf("expected canceled generation to not generate files")
+	}
+}
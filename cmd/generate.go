@@ -4,10 +4,15 @@ import (
 	"context"
 	"fmt"
 	"io"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
 	"strings"
 
 	"github.com/ironman-project/ironman/pkg/ironman"
 
+	"github.com/ironman-project/ironman/pkg/template/values"
 	"github.com/ironman-project/ironman/pkg/template/values/strvals"
 	"github.com/pkg/errors"
 	"github.com/spf13/cobra"
@@ -39,9 +44,14 @@ type generateCmd struct {
 	values          []string
 	stringValues    []string
 	forceGeneration bool
+	exportEnv       bool
 	valFiles        valueFiles
 }
 
+const envPrefix = "IRONMAN_"
+
+var envKeyReplacer = regexp.MustCompile(`[^A-Z0-9_]`)
+
 func newGenerateCommand(client *ironman.Ironman, out io.Writer) *cobra.Command {
 	generate := &generateCmd{
 		out:    out,
@@ -87,6 +97,10 @@ ironman generate template-example ~/mynewapp
 # This generates a project based on template-example template, based on the 'controller' controller
 # and it will generate the files on the '~/mynewapp' directory.
 ironman generate template:example:controller ~/mynewapp
+
+# This generates a project and exports the generation result to the current shell
+# e.g IRONMAN_GENERATION_PATH and IRONMAN_VALUE_APP_NAME.
+eval "$(ironman generate template-example ~/mynewapp --set app.name=myapp --export-env)"
 `,
 		RunE: func(cmd *cobra.Command, args []string) error {
 
@@ -109,7 +123,14 @@ ironman generate template:example:controller ~/mynewapp
 			generate.templateID = templateID
 			generate.generatorID = generatorID
 			generate.path = path
-			generate.client, generate.out = ensureIronmanClientAndOutput(generate.client, generate.out)
+			generate.out = ensureIronmanOutput(generate.out)
+			//when exporting the environment the output should be evaluable by a shell, send the generation progress
+			//and the hooks output to stderr
+			if generate.exportEnv {
+				generate.client = ensureIronmanClientWithProgress(generate.client, os.Stderr, ironman.SetOutput(os.Stderr))
+			} else {
+				generate.client = ensureIronmanClientWithProgress(generate.client, generate.out)
+			}
 			return generate.run()
 		},
 	}
@@ -118,6 +139,7 @@ ironman generate template:example:controller ~/mynewapp
 	f.StringArrayVar(&generate.values, "set", []string{}, "set values on the command line (can specify multiple or separate values with commas: key1=val1,key2=val2)")
 	f.VarP(&generate.valFiles, "values", "f", "specify values in a YAML file (can specify multiple)")
 	f.BoolVar(&generate.forceGeneration, "force", false, "Forces generation even if directory or file exists. e.g ironman generate --force template /generation/path")
+	f.BoolVar(&generate.exportEnv, "export-env", false, "Prints the generation result and values as shell export lines. e.g eval \"$(ironman generate --export-env template /generation/path)\"")
 	return generateCmd
 }
 
@@ -127,11 +149,122 @@ func (g *generateCmd) run() error {
 	if err != nil {
 		return err
 	}
-	fmt.Fprintln(g.out, "Running template generator", g.generatorID)
+	if g.exportEnv {
+		//the generator fields defaults are exported too, fail before generating anything if the values can't be exported
+		values, err = g.client.ResolveValues(g.templateID, g.generatorID, values)
+		if err != nil {
+			return err
+		}
+		if _, err := g.env(values); err != nil {
+			return err
+		}
+	} else {
+		fmt.Fprintln(g.out, "Running template generator", g.generatorID)
+	}
 	err = g.client.Generate(context.Background(), g.templateID, g.generatorID, g.path, values, g.forceGeneration)
 	if err != nil {
 		return err
 	}
+	if g.exportEnv {
+		return g.writeEnv(values)
+	}
 	fmt.Fprintln(g.out, "Done")
 	return nil
 }
+
+//writeEnv writes the generation result and values as shell export lines
+func (g *generateCmd) writeEnv(vals values.Values) error {
+	env, err := g.env(vals)
+	if err != nil {
+		return err
+	}
+
+	keys := make([]string, 0, len(env))
+	for key := range env {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		fmt.Fprintf(g.out, "export %s=%s\n", key, shellQuote(env[key]))
+	}
+	return nil
+}
+
+//env returns the generation result and values as environment variables
+func (g *generateCmd) env(vals values.Values) (map[string]string, error) {
+	generationPath, err := filepath.Abs(g.path)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to get absolute path for generation path %s", g.path)
+	}
+
+	env := map[string]string{
+		envPrefix + "TEMPLATE_ID":     g.templateID,
+		envPrefix + "GENERATOR_ID":    g.generatorID,
+		envPrefix + "GENERATION_PATH": generationPath,
+	}
+	if err := flattenEnv(envPrefix+"VALUE", "", vals, env, map[string]string{}); err != nil {
+		return nil, err
+	}
+	return env, nil
+}
+
+//flattenEnv flattens nested values into env keys e.g app.name => IRONMAN_VALUE_APP_NAME,
+//sources keeps the value path of each key to detect different values exported with the same key
+func flattenEnv(prefix string, path string, value interface{}, env map[string]string, sources map[string]string) error {
+	switch v := value.(type) {
+	case values.Values:
+		return flattenEnv(prefix, path, map[string]interface{}(v), env, sources)
+	case map[string]interface{}:
+		for key, child := range v {
+			if err := flattenEnv(prefix+"_"+envKey(key), childPath(path, key), child, env, sources); err != nil {
+				return err
+			}
+		}
+		return nil
+	case map[interface{}]interface{}:
+		for key, child := range v {
+			name := fmt.Sprint(key)
+			if err := flattenEnv(prefix+"_"+envKey(name), childPath(path, name), child, env, sources); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	if source, ok := sources[prefix]; ok {
+		paths := []string{source, path}
+		sort.Strings(paths)
+		return errors.Errorf("values %s and %s are both exported as %s", paths[0], paths[1], prefix)
+	}
+	sources[prefix] = path
+
+	switch v := value.(type) {
+	case []interface{}:
+		items := make([]string, 0, len(v))
+		for _, item := range v {
+			items = append(items, fmt.Sprint(item))
+		}
+		env[prefix] = strings.Join(items, ",")
+	case nil:
+		env[prefix] = ""
+	default:
+		env[prefix] = fmt.Sprint(v)
+	}
+	return nil
+}
+
+func childPath(path string, key string) string {
+	if path == "" {
+		return key
+	}
+	return path + "." + key
+}
+
+func envKey(key string) string {
+	return envKeyReplacer.ReplaceAllString(strings.ToUpper(key), "_")
+}
+
+func shellQuote(value string) string {
+	return "'" + strings.Replace(value, "'", `'\''`, -1) + "'"
+}
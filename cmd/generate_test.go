@@ -1,10 +1,12 @@
 package cmd
 
 import (
+	"bytes"
 	"io"
 	"io/ioutil"
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
 
 	"github.com/spf13/cobra"
@@ -66,3 +68,75 @@ func TestGenerateCmd(t *testing.T) {
 	}, setUpGenerateCmd, nil)
 
 }
+
+func TestGenerateCmdExportEnv(t *testing.T) {
+	tests := []struct {
+		name     string
+		flags    []string
+		expected string
+		err      string
+	}{
+		{
+			name:  "exports the generation result and values",
+			flags: []string{"--export-env", "--set", "app.name=itsmine,greeting=it's mine"},
+			expected: "export IRONMAN_GENERATION_PATH='{{path}}'\n" +
+				"export IRONMAN_GENERATOR_ID='app'\n" +
+				"export IRONMAN_TEMPLATE_ID='fields-template'\n" +
+				"export IRONMAN_VALUE_APP_NAME='itsmine'\n" +
+				"export IRONMAN_VALUE_APP_PORT='8080'\n" +
+				"export IRONMAN_VALUE_GREETING='it'\\''s mine'\n",
+		},
+		{
+			name:  "values exported with the same key",
+			flags: []string{"--export-env", "--set", "app-name=a,app_name=b"},
+			err:   "values app-name and app_name are both exported as IRONMAN_VALUE_APP_NAME",
+		},
+		{
+			name:  "default value exported with the same key as a value",
+			flags: []string{"--export-env", "--set", "app.name=x,app_port=9090"},
+			err:   "values app.port and app_port are both exported as IRONMAN_VALUE_APP_PORT",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tempHome := testutils.CreateTempDir("ihome", t)
+			defer func() {
+				_ = os.RemoveAll(tempHome)
+			}()
+			testutils.CreateDir(filepath.Join(tempHome, "templates"), t)
+
+			var clientOut bytes.Buffer
+			client := ironman.New(tempHome, ironman.SetOutput(&clientOut))
+			if err := client.Link(testutils.FieldsTemplatePath(), "fields-template"); err != nil {
+				t.Fatalf("failed to link fields template %s", err)
+			}
+
+			var out bytes.Buffer
+			generationPath := filepath.Join(tempHome, "generated")
+			err := testhelpers.RunTestCmd(newGenerateCommand(client, &out), []string{"fields-template", generationPath}, tt.flags)
+			if tt.err != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.err) {
+					t.Errorf("expected error %q, got '%v'", tt.err, err)
+				}
+				if testutils.FileExists(generationPath) {
+					t.Errorf("expected nothing to be generated on %s", generationPath)
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("unexpected error %s", err)
+			}
+
+			//only the export lines should be written on the command output
+			expected := strings.Replace(tt.expected, "{{path}}", generationPath, 1)
+			if got := out.String(); got != expected {
+				t.Errorf("expected\n%q\ngot\n%q", expected, got)
+			}
+
+			if clientOut.Len() != 0 {
+				t.Errorf("expected the client progress to be redirected, got %q", clientOut.String())
+			}
+		})
+	}
+}
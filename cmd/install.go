@@ -34,15 +34,22 @@ func newInstallCommand(client *ironman.Ironman, out io.Writer) *cobra.Command {
 
 			return nil
 		},
-		Short: "Installs a template using a git URL",
-		Long: `Installs a template using a git URL:
+		Short: "Installs a template using a git URL or an archive URL",
+		Long: `Installs a template using a git URL or an archive URL (.tar.gz, .tgz or .zip).
+Archive downloads are resumed if they are interrupted and verified against the sha256 checksum
+published alongside the archive (e.g template.tar.gz.sha256) when there is one. The checksum url keeps the
+archive url query, if it's not found or not accessible the archive is installed without verification.
+Archives are extracted into a staging directory while they are downloaded, the template is only installed
+once the checksum is verified.
 
 Example:
 iroman install https://github.com/ironman-project/template-example.git
+iroman install https://example.com/templates/template-example.tar.gz
 `,
 		RunE: func(cmd *cobra.Command, args []string) error {
 			install.templateLocator = args[0]
-			install.client, install.out = ensureIronmanClientAndOutput(install.client, install.out)
+			install.out = ensureIronmanOutput(install.out)
+			install.client = ensureIronmanClientWithProgress(install.client, install.out)
 			return install.run()
 		},
 	}
@@ -0,0 +1,27 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/ironman-project/ironman/pkg/event"
+)
+
+//progressHandler returns an event handler printing the installation and generation progress into out
+func progressHandler(out io.Writer) event.Handler {
+	return func(e event.Event) {
+		switch e.Type {
+		case event.TypeDownloadRetried:
+			fmt.Fprintf(out, "Download interrupted (%s), retrying %s\n", e.Err, e.Source)
+		case event.TypeChecksumSkipped:
+			fmt.Fprintf(out, "Skipping checksum verification of %s, %s\n", e.Source, strings.Join(e.Messages, ", "))
+		case event.TypeFileWritten:
+			fmt.Fprintln(out, "Writing... ", e.Path)
+		case event.TypeHooksStarted:
+			fmt.Fprintf(out, "Running %s hooks\n", e.Hook)
+		case event.TypeHooksFinished:
+			fmt.Fprintf(out, "\n...Running %s hooks done\n", e.Hook)
+		}
+	}
+}
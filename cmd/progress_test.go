@@ -0,0 +1,33 @@
+package cmd
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/ironman-project/ironman/pkg/event"
+	"github.com/pkg/errors"
+)
+
+func TestProgressHandler(t *testing.T) {
+	tests := []struct {
+		name  string
+		event event.Event
+		want  string
+	}{
+		{"file written", event.Event{Type: event.TypeFileWritten, Path: "/generated/app.txt"}, "Writing...  /generated/app.txt\n"},
+		{"hooks started", event.Event{Type: event.TypeHooksStarted, Hook: "pre-generate"}, "Running pre-generate hooks\n"},
+		{"hooks finished", event.Event{Type: event.TypeHooksFinished, Hook: "post-generate"}, "\n...Running post-generate hooks done\n"},
+		{"download retried", event.Event{Type: event.TypeDownloadRetried, Source: "https://example.com/t.tar.gz", Err: errors.New("unexpected EOF")}, "Download interrupted (unexpected EOF), retrying https://example.com/t.tar.gz\n"},
+		{"checksum skipped", event.Event{Type: event.TypeChecksumSkipped, Source: "https://example.com/t.tar.gz", Messages: []string{"no published checksum found"}}, "Skipping checksum verification of https://example.com/t.tar.gz, no published checksum found\n"},
+		{"not a progress event", event.Event{Type: event.TypeInstallStarted, Source: "https://example.com/t.tar.gz"}, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var out bytes.Buffer
+			progressHandler(&out)(tt.event)
+			if out.String() != tt.want {
+				t.Errorf("progressHandler() output = %q, want %q", out.String(), tt.want)
+			}
+		})
+	}
+}
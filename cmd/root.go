@@ -52,6 +52,7 @@ func newRootCmd() *cobra.Command {
 		newUpdateCmd,
 		newCreateCmd,
 		newDescribeCmd,
+		newBrowseCmd,
 	}
 
 	//add all commands
@@ -117,6 +118,17 @@ func ensureIronmanClient(client *ironman.Ironman) *ironman.Ironman {
 	return client
 }
 
+//ensureIronmanClientWithProgress returns the client printing the progress of its lifecycle events into out,
+//the options are only used to build a new client
+func ensureIronmanClientWithProgress(client *ironman.Ironman, out io.Writer, options ...ironman.Option) *ironman.Ironman {
+	handler := progressHandler(out)
+	if client == nil {
+		return ironman.New(ironmanHome, append(options, ironman.SetEventHandlers(handler))...)
+	}
+	client.Events().Subscribe(handler)
+	return client
+}
+
 func ensureIronmanOutput(out io.Writer) io.Writer {
 	if out == nil {
 		return ironmanOutput()
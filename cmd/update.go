@@ -41,7 +41,8 @@ Example:
 ironman update my-template-id`,
 		RunE: func(cmd *cobra.Command, args []string) error {
 			update.templateID = args[0]
-			update.client, update.out = ensureIronmanClientAndOutput(update.client, update.out)
+			update.out = ensureIronmanOutput(update.out)
+			update.client = ensureIronmanClientWithProgress(update.client, update.out)
 			return update.run()
 		},
 	}
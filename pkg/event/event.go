@@ -0,0 +1,79 @@
+package event
+
+import "sync"
+
+//Type represents the type of a lifecycle event
+type Type string
+
+const (
+	//TypeInstallStarted a template installation started
+	TypeInstallStarted Type = "install.started"
+	//TypeInstallFinished a template installation finished, Err is set if it failed
+	TypeInstallFinished Type = "install.finished"
+	//TypeUpdateStarted a template update started
+	TypeUpdateStarted Type = "update.started"
+	//TypeUpdateFinished a template update finished, Err is set if it failed
+	TypeUpdateFinished Type = "update.finished"
+	//TypeFileWritten a generator wrote a file
+	TypeFileWritten Type = "generation.file.written"
+	//TypeHooksStarted a generator started running the hooks of a stage, Hook is the stage e.g pre-generate
+	TypeHooksStarted Type = "generation.hooks.started"
+	//TypeHooksFinished a generator ran all the hooks of a stage, Hook is the stage e.g pre-generate
+	TypeHooksFinished Type = "generation.hooks.finished"
+	//TypeHookExecuted a generator hook command was executed, Err is set if it failed
+	TypeHookExecuted Type = "generation.hook.executed"
+	//TypeDownloadRetried a template download was interrupted and will be retried, Err is the interruption
+	TypeDownloadRetried Type = "download.retried"
+	//TypeChecksumSkipped a template archive is installed without checksum verification, Messages has the reason
+	TypeChecksumSkipped Type = "download.checksum.skipped"
+	//TypeValidationFailed a template model or the generation values didn't pass the validations
+	TypeValidationFailed Type = "validation.failed"
+)
+
+//Event represents a lifecycle notification. Only the properties that make sense for the event type are set
+type Event struct {
+	Type        Type
+	Source      string
+	TemplateID  string
+	GeneratorID string
+	Path        string
+	Hook        string
+	Messages    []string
+	Err         error
+}
+
+//Handler handles published events. Handlers can be called from different goroutines
+type Handler func(Event)
+
+//Bus dispatches the published events to the subscribed handlers
+type Bus struct {
+	mutex    sync.RWMutex
+	handlers []Handler
+}
+
+//NewBus returns a new instance of an event bus
+func NewBus() *Bus {
+	return &Bus{}
+}
+
+//Subscribe adds a handler that will receive all the events published after subscribing
+func (b *Bus) Subscribe(handler Handler) {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+	b.handlers = append(b.handlers, handler)
+}
+
+//Publish sends the event to all the subscribed handlers in subscription order. It is safe to publish on a nil bus
+func (b *Bus) Publish(event Event) {
+	if b == nil {
+		return
+	}
+
+	b.mutex.RLock()
+	handlers := b.handlers
+	b.mutex.RUnlock()
+
+	for _, handler := range handlers {
+		handler(event)
+	}
+}
@@ -0,0 +1,36 @@
+package event
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestBus_Publish(t *testing.T) {
+	bus := NewBus()
+
+	var first, second []Type
+	bus.Subscribe(func(e Event) {
+		first = append(first, e.Type)
+	})
+
+	bus.Publish(Event{Type: TypeInstallStarted})
+
+	bus.Subscribe(func(e Event) {
+		second = append(second, e.Type)
+	})
+
+	bus.Publish(Event{Type: TypeInstallFinished})
+
+	if want := []Type{TypeInstallStarted, TypeInstallFinished}; !reflect.DeepEqual(first, want) {
+		t.Errorf("Bus.Publish() first handler events = %v, want %v", first, want)
+	}
+
+	if want := []Type{TypeInstallFinished}; !reflect.DeepEqual(second, want) {
+		t.Errorf("Bus.Publish() second handler events = %v, want %v", second, want)
+	}
+}
+
+func TestBus_PublishNil(t *testing.T) {
+	var bus *Bus
+	bus.Publish(Event{Type: TypeInstallStarted})
+}
@@ -0,0 +1,136 @@
+package ironman
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/ironman-project/ironman/pkg/event"
+	"github.com/ironman-project/ironman/pkg/template/manager"
+	"github.com/ironman-project/ironman/pkg/testutils"
+	"github.com/pkg/errors"
+)
+
+var _ manager.Manager = (*fakeManager)(nil)
+
+//fakeManager installs a template linking the local path it is installed from, updates fail with updateErr
+type fakeManager struct {
+	*manager.BaseManager
+	updateErr error
+}
+
+func (f *fakeManager) Install(templateLocator string) (string, error) {
+	return f.Link(templateLocator, filepath.Base(templateLocator))
+}
+
+func (f *fakeManager) Update(templateID string) error {
+	return f.updateErr
+}
+
+func (f *fakeManager) Link(templatePath string, templateID string) (string, error) {
+	absPath, err := filepath.Abs(templatePath)
+	if err != nil {
+		return "", err
+	}
+	return templateID, os.Symlink(absPath, f.TemplateLocation(templateID))
+}
+
+func (f *fakeManager) Unlink(templateID string) error {
+	return os.Remove(f.TemplateLocation(templateID))
+}
+
+func TestIronman_Events(t *testing.T) {
+	fieldsTemplate := testutils.FieldsTemplatePath()
+	invalidTemplate := filepath.Join("testing", "templates", "invalid-fields-template")
+
+	tests := []struct {
+		name      string
+		updateErr error
+		run       func(ir *Ironman) error
+		want      []event.Type
+		wantErr   bool
+	}{
+		{
+			"install",
+			nil,
+			func(ir *Ironman) error { return ir.Install(fieldsTemplate) },
+			[]event.Type{event.TypeInstallStarted, event.TypeInstallFinished},
+			false,
+		},
+		{
+			"install invalid template",
+			nil,
+			func(ir *Ironman) error { return ir.Install(invalidTemplate) },
+			[]event.Type{event.TypeInstallStarted, event.TypeValidationFailed, event.TypeInstallFinished},
+			true,
+		},
+		{
+			"update",
+			nil,
+			func(ir *Ironman) error {
+				if err := ir.Install(fieldsTemplate); err != nil {
+					return err
+				}
+				return ir.Update("fields-template")
+			},
+			[]event.Type{event.TypeInstallStarted, event.TypeInstallFinished, event.TypeUpdateStarted, event.TypeUpdateFinished},
+			false,
+		},
+		{
+			"failed update",
+			errors.New("update failed"),
+			func(ir *Ironman) error {
+				if err := ir.Install(fieldsTemplate); err != nil {
+					return err
+				}
+				return ir.Update("fields-template")
+			},
+			[]event.Type{event.TypeInstallStarted, event.TypeInstallFinished, event.TypeUpdateStarted, event.TypeUpdateFinished},
+			true,
+		},
+		{
+			"update not installed template",
+			nil,
+			func(ir *Ironman) error { return ir.Update("fields-template") },
+			[]event.Type{event.TypeUpdateStarted, event.TypeUpdateFinished},
+			true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got []event.Event
+			ir, clean := newTestIronman(t,
+				SetTemplateIndex(&fakeIndex{}),
+				SetEventHandlers(func(e event.Event) {
+					got = append(got, e)
+				}),
+			)
+			defer clean()
+			//the manager needs the ironman home which is created by newTestIronman
+			SetTemplateManager(&fakeManager{BaseManager: manager.NewBaseManager(ir.home, templatesDirectory), updateErr: tt.updateErr})(ir)
+
+			err := tt.run(ir)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Ironman events error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			var gotTypes []event.Type
+			for _, e := range got {
+				gotTypes = append(gotTypes, e.Type)
+			}
+			if !reflect.DeepEqual(gotTypes, tt.want) {
+				t.Fatalf("Ironman events = %v, want %v", gotTypes, tt.want)
+			}
+
+			last := got[len(got)-1]
+			if last.TemplateID != "fields-template" && last.Source != invalidTemplate {
+				t.Errorf("Ironman events last event = %+v, want the template properties", last)
+			}
+
+			if (last.Err != nil) != tt.wantErr {
+				t.Errorf("Ironman events last event error = %v, wantErr %v", last.Err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -11,10 +11,12 @@ import (
 	"strings"
 	gtemplate "text/template"
 
+	"github.com/ironman-project/ironman/pkg/event"
 	"github.com/ironman-project/ironman/pkg/template"
 	"github.com/ironman-project/ironman/pkg/template/index"
 	"github.com/ironman-project/ironman/pkg/template/index/storm"
 	"github.com/ironman-project/ironman/pkg/template/manager"
+	"github.com/ironman-project/ironman/pkg/template/manager/archive"
 	"github.com/ironman-project/ironman/pkg/template/manager/git"
 	"github.com/ironman-project/ironman/pkg/template/model"
 	"github.com/ironman-project/ironman/pkg/template/validator"
@@ -31,16 +33,19 @@ const (
 	FormatJSON         = "json"
 )
 
-const validatoinTemplateText = ``
+const validatoinTemplateText = `template validation failed:{{range .}}
+{{.}}{{end}}`
 
 //Ironman is the one administering the local
 type Ironman struct {
 	manager                manager.Manager
+	archiveManager         manager.Manager
 	modelReader            model.Reader
 	index                  index.Index
 	home                   string
 	validators             []validator.Validator
 	output                 io.Writer
+	events                 *event.Bus
 	validationTempl        *gtemplate.Template
 	validationTemplateText string
 }
@@ -48,7 +53,7 @@ type Ironman struct {
 //New returns a new instance of ironman
 func New(home string, options ...Option) *Ironman {
 
-	ir := &Ironman{home: home, output: os.Stdout}
+	ir := &Ironman{home: home, output: os.Stdout, events: event.NewBus()}
 
 	for _, option := range options {
 		option(ir)
@@ -64,6 +69,10 @@ func New(home string, options ...Option) *Ironman {
 		ir.manager = manager
 	}
 
+	if ir.archiveManager == nil {
+		ir.archiveManager = archive.New(home, templatesDirectory, archive.SetEventBus(ir.events))
+	}
+
 	if ir.index == nil {
 		indexPath := filepath.Join(home, indexName)
 		index := storm.New(storm.DefaultDBFactory(indexPath))
@@ -76,33 +85,74 @@ func New(home string, options ...Option) *Ironman {
 		ir.modelReader = modelReader
 	}
 
-	if ir.validators == nil {
-		ir.validators = []validator.Validator{}
-	}
+	//generators fields validation rules are always checked
+	ir.validators = append([]validator.Validator{validator.NewFieldsValidator()}, ir.validators...)
 
 	return ir
 }
 
+//templateManager returns the manager in charge of a template source, archive URLs are installed by the archive manager
+func (i *Ironman) templateManager(source string) manager.Manager {
+	if archive.IsArchive(source) {
+		return i.archiveManager
+	}
+	return i.manager
+}
+
+//Events returns the bus where ironman publishes its lifecycle events
+func (i *Ironman) Events() *event.Bus {
+	return i.events
+}
+
 //Install installs a new template based on a template locator
 func (i *Ironman) Install(templateLocator string) error {
+	i.events.Publish(event.Event{Type: event.TypeInstallStarted, Source: templateLocator})
+	templateID, err := i.install(templateLocator)
+	i.events.Publish(event.Event{Type: event.TypeInstallFinished, Source: templateLocator, TemplateID: templateID, Err: err})
+	return err
+}
 
-	templateDirectory, err := i.manager.Install(templateLocator)
+func (i *Ironman) install(templateLocator string) (string, error) {
+
+	manager := i.templateManager(templateLocator)
+	templateDirectory, err := manager.Install(templateLocator)
 
 	if err != nil {
-		return err
+		return "", err
 	}
 
-	templatePath := i.manager.TemplateLocation(templateDirectory)
+	templatePath := manager.TemplateLocation(templateDirectory)
 
 	templateModel, err := i.modelReader.Read(templatePath)
 
 	if err != nil {
 		//rollback manager installation
-		_ = i.manager.Uninstall(templateDirectory)
-		return errors.Wrap(err, "failed to read template model")
+		_ = manager.Uninstall(templateDirectory)
+		return "", errors.Wrap(err, "failed to read template model")
+	}
+
+	if err := i.validate(templateLocator, templateModel); err != nil {
+		//rollback manager installation
+		_ = manager.Uninstall(templateDirectory)
+		return "", err
+	}
+
+	//Set the installation type
+	templateModel.SourceType = model.SourceTypeURL
+	templateModel.Source = templateLocator
+	_, err = i.index.Index(templateModel)
+
+	if err != nil {
+		//rollback manager installation
+		_ = manager.Uninstall(templateDirectory)
+		return "", err
 	}
 
-	//validate model
+	return templateModel.ID, nil
+}
+
+//validate validates the template model with all the validators
+func (i *Ironman) validate(source string, templateModel *model.Template) error {
 	for _, validator := range i.validators {
 		valid, validationErr, err := validator.Validate(templateModel)
 
@@ -111,6 +161,7 @@ func (i *Ironman) Install(templateLocator string) error {
 		}
 
 		if !valid {
+			i.events.Publish(event.Event{Type: event.TypeValidationFailed, Source: source, TemplateID: templateModel.ID, Messages: validationErr})
 			var validationErrBuffer bytes.Buffer
 			err := i.validationTempl.Execute(&validationErrBuffer, validationErr)
 
@@ -121,18 +172,6 @@ func (i *Ironman) Install(templateLocator string) error {
 			return errors.New(validationErrBuffer.String())
 		}
 	}
-
-	//Set the installation type
-	templateModel.SourceType = model.SourceTypeURL
-	templateModel.Source = templateLocator
-	_, err = i.index.Index(templateModel)
-
-	if err != nil {
-		//rollback manager installation
-		_ = i.manager.Uninstall(templateDirectory)
-		return err
-	}
-
 	return nil
 }
 
@@ -152,6 +191,11 @@ func (i *Ironman) Link(templatePath, templateID string) error {
 		return err
 	}
 
+	if err := i.validate(templatePath, templateModel); err != nil {
+		_ = i.manager.Unlink(templateID)
+		return err
+	}
+
 	templateModel.ID = templateID
 	templateModel.SourceType = model.SourceTypeLink
 	templateModel.Source, err = filepath.Abs(templatePath)
@@ -233,35 +277,38 @@ func (i *Ironman) Unlink(templateID string) error {
 
 //Update updates an iroman template
 func (i *Ironman) Update(templateID string) error {
+	i.events.Publish(event.Event{Type: event.TypeUpdateStarted, TemplateID: templateID})
+	source, err := i.update(templateID)
+	i.events.Publish(event.Event{Type: event.TypeUpdateFinished, Source: source, TemplateID: templateID, Err: err})
+	return err
+}
+
+func (i *Ironman) update(templateID string) (string, error) {
 	exists, err := i.index.Exists(templateID)
 
 	if err != nil {
-		return errors.Wrapf(err, "failed to validate if template exists %s", templateID)
+		return "", errors.Wrapf(err, "failed to validate if template exists %s", templateID)
 	}
 
 	if !exists {
-		return errors.Errorf("template '%s' is not installed", templateID)
+		return "", errors.Errorf("template '%s' is not installed", templateID)
 	}
 
 	templateModel, err := i.index.FindTemplateByID(templateID)
 
 	if err != nil {
-		return errors.Wrapf(err, "failed to get template templateModel %s", templateID)
+		return "", errors.Wrapf(err, "failed to get template templateModel %s", templateID)
 	}
 
-	if err = i.manager.Update(templateModel.DirectoryName); err != nil {
-		return err
-	}
-
-	if err != nil {
-		return err
+	if err = i.templateManager(templateModel.Source).Update(templateModel.DirectoryName); err != nil {
+		return templateModel.Source, err
 	}
 
 	if err = i.updateMetadata(templateModel.DirectoryName, templateID, templateModel.Source, model.SourceTypeURL); err != nil {
-		return err
+		return templateModel.Source, err
 	}
 
-	return nil
+	return templateModel.Source, nil
 }
 
 func (i *Ironman) updateMetadata(directoryName string, templateID string, source string, sourceType model.SourceType) error {
@@ -294,29 +341,39 @@ func (i *Ironman) Create(templatePath string) error {
 	return nil
 }
 
-//Generate generates a new file or directory based on a generator
-func (i *Ironman) Generate(context context.Context, templateID string, generatorID string, generationPath string, values values.Values, force bool) error {
+//ResolveValues returns the values a generator runs with, a copy of the values with the default value of the
+//generator fields that were not set
+func (i *Ironman) ResolveValues(templateID string, generatorID string, vals values.Values) (values.Values, error) {
+	_, generator, err := i.generator(templateID, generatorID)
+	if err != nil {
+		return nil, err
+	}
+	return resolveValues(generator, vals)
+}
+
+//generator returns an installed template model and its generator model
+func (i *Ironman) generator(templateID string, generatorID string) (*model.Template, *model.Generator, error) {
 	//First validate if template exists
 	exists, err := i.index.Exists(templateID)
 
 	if err != nil {
-		return errors.Wrapf(err, "failed to validate if template exists %s", templateID)
+		return nil, nil, errors.Wrapf(err, "failed to validate if template exists %s", templateID)
 	}
 
 	if !exists {
-		return errors.Errorf("template '%s' is not installed", templateID)
+		return nil, nil, errors.Errorf("template '%s' is not installed", templateID)
 	}
 
 	templateModel, err := i.index.FindTemplateByID(templateID)
 	if err != nil {
-		return errors.Wrapf(err, "could not find template by ID %s", templateID)
+		return nil, nil, errors.Wrapf(err, "could not find template by ID %s", templateID)
 	}
 
 	//Update metadata of the template automatically if the template type is a link
 	if templateModel.SourceType == model.SourceTypeLink {
 		err = i.updateMetadata(templateModel.DirectoryName, templateID, templateModel.Source, model.SourceTypeLink)
 		if err != nil {
-			return err
+			return nil, nil, err
 		}
 	}
 
@@ -324,7 +381,31 @@ func (i *Ironman) Generate(context context.Context, templateID string, generator
 	genteratorModel := templateModel.Generator(generatorID)
 
 	if genteratorModel == nil {
-		return errors.Errorf("generator %s does not exists", generatorID)
+		return nil, nil, errors.Errorf("generator %s does not exists", generatorID)
+	}
+	return templateModel, genteratorModel, nil
+}
+
+//Generate generates a new file or directory based on a generator
+func (i *Ironman) Generate(context context.Context, templateID string, generatorID string, generationPath string, values values.Values, force bool) error {
+	templateModel, genteratorModel, err := i.generator(templateID, generatorID)
+	if err != nil {
+		return err
+	}
+
+	values, err = resolveValues(genteratorModel, values)
+	if err != nil {
+		return err
+	}
+
+	messages, err := validateValues(genteratorModel, values)
+	if err != nil {
+		return err
+	}
+
+	if len(messages) > 0 {
+		i.events.Publish(event.Event{Type: event.TypeValidationFailed, TemplateID: templateID, GeneratorID: generatorID, Messages: messages})
+		return errors.Errorf("generator %s values validation failed:\n%s", generatorID, strings.Join(messages, "\n"))
 	}
 
 	absGenerationPath, err := filepath.Abs(generationPath)
@@ -381,6 +462,7 @@ func (i *Ironman) Generate(context context.Context, templateID string, generator
 		absGenerationPath,
 		data,
 		template.SetGeneratorOutput(i.output),
+		template.SetGeneratorEventBus(i.events),
 	)
 
 	if err := generator.Generate(context); err != nil {
@@ -390,6 +472,54 @@ func (i *Ironman) Generate(context context.Context, templateID string, generator
 	return nil
 }
 
+//resolveValues returns a copy of the values with the generator fields default values that were not set,
+//the caller values are never changed
+func resolveValues(generator *model.Generator, vals values.Values) (values.Values, error) {
+	resolved := vals.Copy()
+	if err := applyDefaults(generator, resolved); err != nil {
+		return nil, err
+	}
+	return resolved, nil
+}
+
+//applyDefaults sets the generator fields default values that were not set
+func applyDefaults(generator *model.Generator, vals values.Values) error {
+	for _, field := range generator.Fields {
+		if field.Default == nil {
+			continue
+		}
+
+		if _, ok := vals.Lookup(field.ID); ok {
+			continue
+		}
+
+		if err := vals.Set(field.ID, field.Default); err != nil {
+			return errors.Wrapf(err, "failed to set default value for field %s", field.ID)
+		}
+	}
+	return nil
+}
+
+//validateValues validates the values against the generator fields validation rules, returns the validation errors messages
+//and an error if the fields validation rules themselves are not valid
+func validateValues(generator *model.Generator, vals values.Values) ([]string, error) {
+	var messages []string
+	for _, field := range generator.Fields {
+		value, ok := vals.Lookup(field.ID)
+		if !ok {
+			continue
+		}
+
+		err := field.Validate(value)
+		if fieldErr, ok := err.(*model.FieldError); ok {
+			messages = append(messages, fieldErr.Error())
+		} else if err != nil {
+			return nil, errors.Wrapf(err, "generator %s has invalid fields", generator.ID)
+		}
+	}
+	return messages, nil
+}
+
 func isDirEmpty(name string) (bool, error) {
 	f, err := os.Open(name)
 	if err != nil {
@@ -445,7 +575,7 @@ func (i *Ironman) Describe(resourceID string, format string, writer io.Writer) e
 	idTokens := strings.Split(resourceID, ":")
 	idTokensLen := len(idTokens)
 	if !(idTokensLen == 1 || idTokensLen == 2) {
-		return errors.Errorf("invalid number of tokens in id %s tokens:%d", resourceID, idTokens)
+		return errors.Errorf("invalid number of tokens in id %s tokens:%d", resourceID, idTokensLen)
 	}
 
 	var templateID = idTokens[0]
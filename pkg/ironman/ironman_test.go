@@ -0,0 +1,132 @@
+package ironman
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/ironman-project/ironman/pkg/template/values"
+	"github.com/ironman-project/ironman/pkg/testutils"
+)
+
+func newTestIronman(t *testing.T, options ...Option) (*Ironman, func()) {
+	home := testutils.CreateTempDir("ironman-home", t)
+	testutils.CreateDir(filepath.Join(home, templatesDirectory), t)
+	options = append([]Option{SetOutput(ioutil.Discard)}, options...)
+	return New(home, options...), func() {
+		_ = os.RemoveAll(home)
+	}
+}
+
+func TestIronman_Generate(t *testing.T) {
+	tests := []struct {
+		name     string
+		values   values.Values
+		want     string
+		errorMsg string
+	}{
+		{"default values", values.Values{"app": map[string]interface{}{"name": "ironman"}}, "ironman:8080\n", ""},
+		{"passed values", values.Values{"app": map[string]interface{}{"name": "ironman", "port": int64(9090)}}, "ironman:9090\n", ""},
+		{
+			"invalid values",
+			values.Values{"app": map[string]interface{}{"name": "Iron Man", "port": int64(80)}},
+			"",
+			"invalid value 'Iron Man' for field 'app.name': should match pattern ^[a-z]+$\ninvalid value '80' for field 'app.port': should be greater than or equal to 1024",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ir, clean := newTestIronman(t)
+			defer clean()
+
+			if err := ir.Link(testutils.FieldsTemplatePath(), "fields-template"); err != nil {
+				t.Fatalf("Ironman.Generate() failed to link template %s", err)
+			}
+
+			generationPath := filepath.Join(ir.home, "generated")
+			err := ir.Generate(context.Background(), "fields-template", "app", generationPath, tt.values, false)
+			if tt.errorMsg != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.errorMsg) {
+					t.Errorf("Ironman.Generate() error = %v, want %s", err, tt.errorMsg)
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("Ironman.Generate() error = %v", err)
+			}
+
+			if got := testutils.ReadFile(t, generationPath, "app.txt"); got != tt.want {
+				t.Errorf("Ironman.Generate() app.txt = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestIronman_ResolveValues(t *testing.T) {
+	tests := []struct {
+		name     string
+		values   values.Values
+		want     values.Values
+		errorMsg string
+	}{
+		{"nil values", nil, values.Values{"app": map[string]interface{}{"port": float64(8080)}}, ""},
+		{"default values", values.Values{"app": map[string]interface{}{"name": "ironman"}}, values.Values{"app": map[string]interface{}{"name": "ironman", "port": float64(8080)}}, ""},
+		{"passed values", values.Values{"app": map[string]interface{}{"port": int64(9090)}}, values.Values{"app": map[string]interface{}{"port": int64(9090)}}, ""},
+		{"not installed template", values.Values{}, nil, "failed to validate if template exists other-template"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ir, clean := newTestIronman(t)
+			defer clean()
+
+			if err := ir.Link(testutils.FieldsTemplatePath(), "fields-template"); err != nil {
+				t.Fatalf("Ironman.ResolveValues() failed to link template %s", err)
+			}
+
+			templateID := "fields-template"
+			if tt.errorMsg != "" {
+				templateID = "other-template"
+			}
+
+			original := tt.values.Copy()
+			got, err := ir.ResolveValues(templateID, "app", tt.values)
+			if tt.errorMsg != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.errorMsg) {
+					t.Errorf("Ironman.ResolveValues() error = %v, want %s", err, tt.errorMsg)
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("Ironman.ResolveValues() error = %v", err)
+			}
+
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Ironman.ResolveValues() = %v, want %v", got, tt.want)
+			}
+
+			if tt.values != nil && !reflect.DeepEqual(tt.values, original) {
+				t.Errorf("Ironman.ResolveValues() changed the passed values %v, want %v", tt.values, original)
+			}
+		})
+	}
+}
+
+func TestIronman_LinkInvalidFields(t *testing.T) {
+	ir, clean := newTestIronman(t)
+	defer clean()
+
+	err := ir.Link(filepath.Join("testing", "templates", "invalid-fields-template"), "invalid-fields-template")
+	if err == nil || !strings.Contains(err.Error(), "invalid validation pattern for field app.name") {
+		t.Errorf("Ironman.Link() error = %v, want invalid validation pattern", err)
+	}
+
+	if exists, _ := ir.index.Exists("invalid-fields-template"); exists {
+		t.Errorf("Ironman.Link() template with invalid fields should not be indexed")
+	}
+}
@@ -3,6 +3,7 @@ package ironman
 import (
 	"io"
 
+	"github.com/ironman-project/ironman/pkg/event"
 	"github.com/ironman-project/ironman/pkg/template/index"
 	"github.com/ironman-project/ironman/pkg/template/manager"
 	"github.com/ironman-project/ironman/pkg/template/validator"
@@ -18,6 +19,13 @@ func SetTemplateManager(manager manager.Manager) Option {
 	}
 }
 
+//SetArchiveTemplateManager sets ironman's template manager for archive URLs
+func SetArchiveTemplateManager(manager manager.Manager) Option {
+	return func(i *Ironman) {
+		i.archiveManager = manager
+	}
+}
+
 //SetTemplateIndex sets the ironman template index
 func SetTemplateIndex(index index.Index) Option {
 	return func(i *Ironman) {
@@ -40,3 +48,12 @@ func SetOutput(output io.Writer) Option {
 		i.output = output
 	}
 }
+
+//SetEventHandlers subscribes the handlers to ironman lifecycle events
+func SetEventHandlers(handlers ...event.Handler) Option {
+	return func(i *Ironman) {
+		for _, handler := range handlers {
+			i.events.Subscribe(handler)
+		}
+	}
+}
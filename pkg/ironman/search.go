@@ -0,0 +1,98 @@
+package ironman
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/ironman-project/ironman/pkg/template/model"
+)
+
+type searchResult struct {
+	template *model.Template
+	score    int
+}
+
+//Search returns the installed templates that fuzzy match the query sorted by relevance.
+//An empty query returns all the installed templates
+func (i *Ironman) Search(query string) ([]*model.Template, error) {
+	templates, err := i.index.List()
+	if err != nil {
+		return nil, err
+	}
+	return FilterTemplates(templates, query), nil
+}
+
+//FilterTemplates returns the templates that fuzzy match the query sorted by relevance, an empty query returns all the templates
+func FilterTemplates(templates []*model.Template, query string) []*model.Template {
+	query = strings.ToLower(strings.TrimSpace(query))
+	if query == "" {
+		return templates
+	}
+
+	var results []searchResult
+	for _, template := range templates {
+		if score, ok := templateScore(template, query); ok {
+			results = append(results, searchResult{template, score})
+		}
+	}
+
+	sort.SliceStable(results, func(a, b int) bool {
+		return results[a].score < results[b].score
+	})
+
+	matches := make([]*model.Template, 0, len(results))
+	for _, result := range results {
+		matches = append(matches, result.template)
+	}
+	return matches
+}
+
+//templateScore returns the best score between the template searchable properties, lower is better
+func templateScore(template *model.Template, query string) (int, bool) {
+	candidates := []string{template.ID, template.Name, template.Description}
+	for _, generator := range template.Generators {
+		candidates = append(candidates, generator.ID, generator.Name)
+	}
+
+	best, found := 0, false
+	for weight, candidate := range candidates {
+		span, ok := fuzzyMatch(strings.ToLower(candidate), query)
+		if !ok {
+			continue
+		}
+		//matches on the first properties (id, name) are more relevant than the ones on the generators
+		score := span*len(candidates) + weight
+		if !found || score < best {
+			best, found = score, true
+		}
+	}
+	return best, found
+}
+
+//fuzzyMatch returns whether all the query characters appear in order in the text
+//and the length of the shortest text span containing them
+func fuzzyMatch(text string, query string) (int, bool) {
+	textRunes := []rune(text)
+	queryRunes := []rune(query)
+	best, found := 0, false
+	for start := range textRunes {
+		if textRunes[start] != queryRunes[0] {
+			continue
+		}
+		q := 0
+		for end := start; end < len(textRunes); end++ {
+			if textRunes[end] != queryRunes[q] {
+				continue
+			}
+			q++
+			if q == len(queryRunes) {
+				span := end - start + 1
+				if !found || span < best {
+					best, found = span, true
+				}
+				break
+			}
+		}
+	}
+	return best, found
+}
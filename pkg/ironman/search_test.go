@@ -0,0 +1,119 @@
+package ironman
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/ironman-project/ironman/pkg/template/index"
+	"github.com/ironman-project/ironman/pkg/template/model"
+)
+
+var _ index.Index = (*fakeIndex)(nil)
+
+//fakeIndex an in memory index keeping the templates in insertion order
+type fakeIndex struct {
+	templates []*model.Template
+}
+
+func (f *fakeIndex) Index(template *model.Template) (string, error) {
+	f.templates = append(f.templates, template)
+	return template.ID, nil
+}
+
+func (f *fakeIndex) Update(template *model.Template) error {
+	for n, t := range f.templates {
+		if t.ID == template.ID {
+			f.templates[n] = template
+		}
+	}
+	return nil
+}
+
+func (f *fakeIndex) Delete(ID string) (bool, error) {
+	for n, t := range f.templates {
+		if t.ID == ID {
+			f.templates = append(f.templates[:n], f.templates[n+1:]...)
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+func (f *fakeIndex) List() ([]*model.Template, error) {
+	return f.templates, nil
+}
+
+func (f *fakeIndex) FindTemplateByID(ID string) (*model.Template, error) {
+	for _, t := range f.templates {
+		if t.ID == ID {
+			return t, nil
+		}
+	}
+	return nil, nil
+}
+
+func (f *fakeIndex) Exists(ID string) (bool, error) {
+	t, _ := f.FindTemplateByID(ID)
+	return t != nil, nil
+}
+
+func Test_fuzzyMatch(t *testing.T) {
+	tests := []struct {
+		text     string
+		query    string
+		wantSpan int
+		wantOk   bool
+	}{
+		{"template-example", "template", 8, true},
+		{"template-example", "tex", 5, true},
+		{"template-example", "tple", 8, true},
+		{"template-example", "elpmet", 0, false},
+		{"go http server", "ghs", 9, true},
+		{"abc", "abcd", 0, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.text+"/"+tt.query, func(t *testing.T) {
+			span, ok := fuzzyMatch(tt.text, tt.query)
+			if ok != tt.wantOk || span != tt.wantSpan {
+				t.Errorf("fuzzyMatch() = %d, %v want %d, %v", span, ok, tt.wantSpan, tt.wantOk)
+			}
+		})
+	}
+}
+
+func TestIronman_Search(t *testing.T) {
+	idx := &fakeIndex{templates: []*model.Template{
+		{ID: "java-spring", Name: "Java Spring", Description: "A spring boot application"},
+		{ID: "go-http", Name: "Go HTTP", Description: "A simple go http server"},
+		{ID: "react-app", Name: "React App", Description: "A react application", Generators: []*model.Generator{{ID: "http-client"}}},
+	}}
+	ir, clean := newTestIronman(t, SetTemplateIndex(idx))
+	defer clean()
+
+	tests := []struct {
+		name  string
+		query string
+		want  []string
+	}{
+		{"empty query returns all", "", []string{"java-spring", "go-http", "react-app"}},
+		{"exact id first", "go-http", []string{"go-http"}},
+		{"shorter span first then generators", "http", []string{"go-http", "react-app"}},
+		{"case insensitive", "SPRING", []string{"java-spring"}},
+		{"no match", "zzz", []string{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			templates, err := ir.Search(tt.query)
+			if err != nil {
+				t.Fatalf("Ironman.Search() error = %v", err)
+			}
+			got := []string{}
+			for _, template := range templates {
+				got = append(got, template.ID)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Ironman.Search() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,101 @@
+package prompt
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/ironman-project/ironman/pkg/template/model"
+	"github.com/ironman-project/ironman/pkg/template/values"
+	"github.com/pkg/errors"
+	"k8s.io/helm/pkg/strvals"
+)
+
+//valueEscaper escapes the runes --set parsing gives a meaning to, so a prompted value is always a single value
+var valueEscaper = strings.NewReplacer(`\`, `\\`, `,`, `\,`, `{`, `\{`)
+
+//Prompter asks the user for input in a line based terminal
+type Prompter struct {
+	scanner *bufio.Scanner
+	out     io.Writer
+}
+
+//New returns a new instance of a prompter
+func New(in io.Reader, out io.Writer) *Prompter {
+	return &Prompter{
+		scanner: bufio.NewScanner(in),
+		out:     out,
+	}
+}
+
+//String asks for a line of text, returns the default value if the line is empty
+func (p *Prompter) String(label string, defaultValue string) (string, error) {
+	if defaultValue != "" {
+		fmt.Fprintf(p.out, "%s [%s]: ", label, defaultValue)
+	} else {
+		fmt.Fprintf(p.out, "%s: ", label)
+	}
+
+	if !p.scanner.Scan() {
+		if err := p.scanner.Err(); err != nil {
+			return "", errors.Wrap(err, "failed to read input")
+		}
+		return "", io.EOF
+	}
+
+	line := strings.TrimSpace(p.scanner.Text())
+	if line == "" {
+		return defaultValue, nil
+	}
+	return line, nil
+}
+
+//Field asks for a field value until it passes the field validation rules. An empty value is not validated since fields are optional
+func (p *Prompter) Field(field *model.Field) (string, error) {
+	label := field.ID
+	if field.Description != "" {
+		label = fmt.Sprintf("%s (%s)", field.ID, field.Description)
+	}
+
+	defaultValue := ""
+	if field.Default != nil {
+		defaultValue = fmt.Sprint(field.Default)
+	}
+
+	for {
+		value, err := p.String(label, defaultValue)
+		if err != nil {
+			return "", err
+		}
+
+		if value == "" {
+			return "", nil
+		}
+
+		if err := field.Validate(value); err != nil {
+			fmt.Fprintln(p.out, err)
+			continue
+		}
+		return value, nil
+	}
+}
+
+//Fields asks for the values of all the fields and sets them into vals, values are typed the same way --set values are
+func (p *Prompter) Fields(fields []*model.Field, vals values.Values) error {
+	for _, field := range fields {
+		value, err := p.Field(field)
+		if err != nil {
+			return err
+		}
+
+		if value == "" {
+			continue
+		}
+
+		if err := strvals.ParseInto(field.ID+"="+valueEscaper.Replace(value), vals); err != nil {
+			return errors.Wrapf(err, "failed to set value for field %s", field.ID)
+		}
+	}
+	return nil
+}
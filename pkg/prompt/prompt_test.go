@@ -0,0 +1,85 @@
+package prompt
+
+import (
+	"bytes"
+	"io"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/ironman-project/ironman/pkg/template/model"
+	"github.com/ironman-project/ironman/pkg/template/values"
+)
+
+func TestPrompter_String(t *testing.T) {
+	tests := []struct {
+		name         string
+		input        string
+		defaultValue string
+		want         string
+		wantErr      error
+	}{
+		{"read line", "hello\n", "", "hello", nil},
+		{"trim line", "  hello  \n", "", "hello", nil},
+		{"default value", "\n", "default", "default", nil},
+		{"end of input", "", "", "", io.EOF},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := New(strings.NewReader(tt.input), &bytes.Buffer{})
+			got, err := p.String("label", tt.defaultValue)
+			if err != tt.wantErr {
+				t.Errorf("Prompter.String() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if got != tt.want {
+				t.Errorf("Prompter.String() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPrompter_Fields(t *testing.T) {
+	maxLength := 5
+	min := 1.0
+	fields := []*model.Field{
+		{
+			ID:         "app.name",
+			Validation: &model.FieldValidation{MaxLength: &maxLength},
+		},
+		{
+			ID:      "app.port",
+			Default: 8080,
+		},
+		{
+			ID:         "app.replicas",
+			Validation: &model.FieldValidation{Min: &min},
+		},
+		{
+			ID: "app.tags",
+		},
+	}
+
+	var out bytes.Buffer
+	p := New(strings.NewReader("toolongname\nshort\n\n\na,{b}\n"), &out)
+	got := values.Values{}
+	if err := p.Fields(fields, got); err != nil {
+		t.Fatalf("Prompter.Fields() error = %v", err)
+	}
+
+	want := values.Values{
+		"app": map[string]interface{}{
+			"name": "short",
+			"port": int64(8080),
+			"tags": "a,{b}",
+		},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Prompter.Fields() = %#v, want %#v", got, want)
+	}
+
+	expectedErr := "invalid value 'toolongname' for field 'app.name': should have at most 5 characters"
+	if !strings.Contains(out.String(), expectedErr) {
+		t.Errorf("Prompter.Fields() output = %q, expected to contain %q", out.String(), expectedErr)
+	}
+}
@@ -0,0 +1,13 @@
+package registry
+
+import "net/http"
+
+//Option represents a registry setter
+type Option func(registry *HTTPRegistry)
+
+//SetHTTPClient sets the http client used to get the registry document
+func SetHTTPClient(client *http.Client) Option {
+	return func(registry *HTTPRegistry) {
+		registry.client = client
+	}
+}
@@ -0,0 +1,79 @@
+package registry
+
+import (
+	"io"
+	"io/ioutil"
+	"net/http"
+	"time"
+
+	"github.com/ironman-project/ironman/pkg/template/model"
+	"github.com/pkg/errors"
+	yaml "gopkg.in/yaml.v2"
+)
+
+const maxDocumentSize = 10 << 20
+
+//Registry represents a catalog of templates that can be installed
+type Registry interface {
+	Templates() ([]*model.Template, error)
+}
+
+//document the registry document format, a list of templates with the source they are installed from e.g
+//
+//templates:
+//  - id: simple-gohttp
+//    name: Simple Go HTTP Template
+//    description: A simple HTTP Go library template
+//    source: https://github.com/ironman-project/simple-gohttp-template.git
+type document struct {
+	Templates []*model.Template `yaml:"templates"`
+}
+
+//HTTPRegistry represents a registry whose document is served over http
+type HTTPRegistry struct {
+	url    string
+	client *http.Client
+}
+
+//New returns a new instance of a registry reading the registry document from an URL
+func New(url string, options ...Option) Registry {
+	r := &HTTPRegistry{
+		url:    url,
+		client: &http.Client{Timeout: 30 * time.Second},
+	}
+
+	for _, option := range options {
+		option(r)
+	}
+	return r
+}
+
+//Templates returns the templates listed in the registry
+func (r *HTTPRegistry) Templates() ([]*model.Template, error) {
+	resp, err := r.client.Get(r.url)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to get registry %s", r.url)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, errors.Errorf("failed to get registry %s unexpected status %s", r.url, resp.Status)
+	}
+
+	data, err := ioutil.ReadAll(io.LimitReader(resp.Body, maxDocumentSize))
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to read registry %s", r.url)
+	}
+
+	var doc document
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return nil, errors.Wrapf(err, "failed to decode registry %s", r.url)
+	}
+
+	for n, template := range doc.Templates {
+		if template == nil || template.ID == "" || template.Source == "" {
+			return nil, errors.Errorf("invalid registry %s template %d should have an id and a source", r.url, n+1)
+		}
+	}
+	return doc.Templates, nil
+}
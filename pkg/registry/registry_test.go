@@ -0,0 +1,60 @@
+package registry
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestHTTPRegistry_Templates(t *testing.T) {
+	tests := []struct {
+		name     string
+		document string
+		status   int
+		wantIDs  []string
+		wantErr  string
+	}{
+		{
+			"list templates",
+			"templates:\n  - id: simple-gohttp\n    name: Simple Go HTTP\n    source: https://example.com/simple-gohttp.git\n  - id: archived\n    source: https://example.com/archived.tar.gz\n",
+			http.StatusOK,
+			[]string{"simple-gohttp", "archived"},
+			"",
+		},
+		{"empty registry", "templates: []\n", http.StatusOK, nil, ""},
+		{"template without source", "templates:\n  - id: simple-gohttp\n", http.StatusOK, nil, "template 1 should have an id and a source"},
+		{"invalid document", "templates: {", http.StatusOK, nil, "failed to decode registry"},
+		{"registry not found", "", http.StatusNotFound, nil, "unexpected status 404"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(tt.status)
+				w.Write([]byte(tt.document))
+			}))
+			defer server.Close()
+
+			templates, err := New(server.URL + "/registry.yaml").Templates()
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Errorf("HTTPRegistry.Templates() error = %v, wantErr %s", err, tt.wantErr)
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("HTTPRegistry.Templates() error = %v", err)
+			}
+
+			var ids []string
+			for _, template := range templates {
+				ids = append(ids, template.ID)
+			}
+			if !reflect.DeepEqual(ids, tt.wantIDs) {
+				t.Errorf("HTTPRegistry.Templates() ids = %v, want %v", ids, tt.wantIDs)
+			}
+		})
+	}
+}
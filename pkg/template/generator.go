@@ -3,7 +3,6 @@ package template
 import (
 	"bytes"
 	"context"
-	"fmt"
 	"io"
 	"io/ioutil"
 	"os"
@@ -11,6 +10,7 @@ import (
 	"strings"
 	"sync"
 
+	"github.com/ironman-project/ironman/pkg/event"
 	"github.com/ironman-project/ironman/pkg/template/engine"
 	"github.com/ironman-project/ironman/pkg/template/engine/goengine"
 	"github.com/ironman-project/ironman/pkg/template/model"
@@ -46,6 +46,7 @@ type generator struct {
 	data                  GeneratorData
 	engineFactory         engine.Factory
 	out                   io.Writer
+	events                *event.Bus
 	withPreGenerateHooks  bool
 	withPostGenerateHooks bool
 }
@@ -313,8 +314,6 @@ func (g *generator) writeFile(presult processResult) writeResult {
 		return writeResult{pathFrom: presult.templatePathResult.path, pathTo: toPath}
 	}
 
-	fmt.Fprintln(g.out, "Writing... ", toPath)
-
 	//Create directory
 	dir := filepath.Dir(toPath)
 	if _, err := os.Stat(dir); os.IsNotExist(err) {
@@ -331,6 +330,8 @@ func (g *generator) writeFile(presult processResult) writeResult {
 	if err != nil {
 		return writeResult{err: err}
 	}
+
+	g.publish(event.Event{Type: event.TypeFileWritten, Path: toPath})
 	return writeResult{pathFrom: presult.templatePathResult.path, pathTo: toPath}
 }
 
@@ -356,13 +357,15 @@ func (g *generator) runHooks(name string, hooks []*model.Command) error {
 		return nil // do nothing
 	}
 
-	fmt.Fprintf(g.out, "Running %s hooks\n", name)
+	g.publish(event.Event{Type: event.TypeHooksStarted, Hook: name})
 	for _, hookCommand := range hooks {
-		if err := g.executeCommand(hookCommand); err != nil {
+		err := g.executeCommand(hookCommand)
+		g.publish(event.Event{Type: event.TypeHookExecuted, Hook: hookCommand.Name, Err: err})
+		if err != nil {
 			return errors.Errorf("failed to execute %s hook %s %s", name, hookCommand.Name, err)
 		}
 	}
-	fmt.Fprintf(g.out, "\n...Running %s hooks done\n", name)
+	g.publish(event.Event{Type: event.TypeHooksFinished, Hook: name})
 
 	return nil
 }
@@ -408,3 +411,14 @@ func (g *generator) executeCommand(command *model.Command) error {
 	}
 	return nil
 }
+
+//publish publishes a generation event including the template and generator
+func (g *generator) publish(e event.Event) {
+	if g.data.Template != nil {
+		e.TemplateID = g.data.Template.ID
+	}
+	if g.data.Generator != nil {
+		e.GeneratorID = g.data.Generator.ID
+	}
+	g.events.Publish(e)
+}
@@ -3,6 +3,7 @@ package template
 import (
 	"io"
 
+	"github.com/ironman-project/ironman/pkg/event"
 	"github.com/ironman-project/ironman/pkg/template/engine"
 )
 
@@ -16,6 +17,13 @@ func SetGeneratorOutput(out io.Writer) GeneratorOption {
 	}
 }
 
+//SetGeneratorEventBus sets the bus where the generation events are published
+func SetGeneratorEventBus(events *event.Bus) GeneratorOption {
+	return func(generator *generator) {
+		generator.events = events
+	}
+}
+
 //SetGeneratorEngine sets the generator template engine
 func SetGeneratorEngine(engine engine.Factory) GeneratorOption {
 	return func(generator *generator) {
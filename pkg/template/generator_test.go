@@ -6,8 +6,12 @@ import (
 	"io/ioutil"
 	"os"
 	"path/filepath"
+	"reflect"
+	"sort"
+	"sync"
 	"testing"
 
+	"github.com/ironman-project/ironman/pkg/event"
 	"github.com/ironman-project/ironman/pkg/template/engine"
 	"github.com/ironman-project/ironman/pkg/template/engine/goengine"
 	"github.com/ironman-project/ironman/pkg/template/model"
@@ -169,6 +173,46 @@ func Test_generator_Generate(t *testing.T) {
 	}
 }
 
+func Test_generator_GenerateEvents(t *testing.T) {
+	tempDir := testutils.CreateTempDir("test_events", t)
+	defer func() {
+		_ = os.RemoveAll(tempDir)
+	}()
+
+	var mutex sync.Mutex
+	var written []string
+	bus := event.NewBus()
+	bus.Subscribe(func(e event.Event) {
+		mutex.Lock()
+		defer mutex.Unlock()
+		if e.Type == event.TypeFileWritten && e.GeneratorID == "app" {
+			written = append(written, e.Path)
+		}
+	})
+
+	g := NewGenerator(
+		filepath.Join("testing", "templates", "valid", "app"),
+		tempDir,
+		GeneratorData{
+			&model.Template{ID: "test"},
+			&model.Generator{ID: "app"},
+			values.Values{"foo": "bar", "bar": "foo"},
+		},
+		SetGeneratorOutput(ioutil.Discard),
+		SetGeneratorEventBus(bus),
+	)
+
+	if err := g.Generate(context.Background()); err != nil {
+		t.Fatalf("generator.Generate() error = %v", err)
+	}
+
+	sort.Strings(written)
+	want := []string{filepath.Join(tempDir, "hi.js"), filepath.Join(tempDir, "internal", "hi.js")}
+	if !reflect.DeepEqual(written, want) {
+		t.Errorf("generator.Generate() written files events = %v, want %v", written, want)
+	}
+}
+
 func Test_generator_runHooks(t *testing.T) {
 	type fields struct {
 		data GeneratorData
@@ -182,6 +226,7 @@ func Test_generator_runHooks(t *testing.T) {
 		fields     fields
 		args       args
 		wantOutput string
+		wantEvents []event.Type
 		wantErr    bool
 	}{
 		{
@@ -213,7 +258,8 @@ func Test_generator_runHooks(t *testing.T) {
 					},
 				},
 			},
-			wantOutput: "Running pre-generate hooks\nTemplate test Version 1.0 Generator gen-test with values with some-value value\n...Running pre-generate hooks done\n",
+			wantOutput: "Template test Version 1.0 Generator gen-test with values with some-value value",
+			wantEvents: []event.Type{event.TypeHooksStarted, event.TypeHookExecuted, event.TypeHooksFinished},
 			wantErr:    false,
 		},
 		{
@@ -227,7 +273,8 @@ func Test_generator_runHooks(t *testing.T) {
 					&model.Command{},
 				},
 			},
-			wantOutput: "Running nonexistingcommand hooks\n",
+			wantOutput: "",
+			wantEvents: []event.Type{event.TypeHooksStarted, event.TypeHookExecuted},
 			wantErr:    true,
 		},
 		{
@@ -241,7 +288,8 @@ func Test_generator_runHooks(t *testing.T) {
 					&model.Command{},
 				},
 			},
-			wantOutput: "Running  hooks\n",
+			wantOutput: "",
+			wantEvents: []event.Type{event.TypeHooksStarted, event.TypeHookExecuted},
 			wantErr:    true,
 		},
 		{
@@ -261,10 +309,16 @@ func Test_generator_runHooks(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 
 			var output bytes.Buffer
+			var gotEvents []event.Type
+			events := event.NewBus()
+			events.Subscribe(func(e event.Event) {
+				gotEvents = append(gotEvents, e.Type)
+			})
 			g := &generator{
 				data:          tt.fields.data,
 				engineFactory: engineFactory,
 				out:           &output,
+				events:        events,
 			}
 
 			if err := g.runHooks(tt.args.name, tt.args.hooks); (err != nil) != tt.wantErr {
@@ -276,6 +330,10 @@ func Test_generator_runHooks(t *testing.T) {
 				t.Errorf("generator.runHooks() = %v, want %v", gotOutput, tt.wantOutput)
 			}
 
+			if !reflect.DeepEqual(gotEvents, tt.wantEvents) {
+				t.Errorf("generator.runHooks() events = %v, want %v", gotEvents, tt.wantEvents)
+			}
+
 		})
 	}
 }
@@ -0,0 +1,163 @@
+package archive
+
+import (
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/ironman-project/ironman/pkg/event"
+	"github.com/ironman-project/ironman/pkg/template/manager"
+	"github.com/pkg/errors"
+)
+
+const (
+	downloadsDirectory = "downloads"
+	sourceFileName     = ".ironman-archive"
+	defaultRetries     = 5
+	defaultBackoff     = time.Second
+	defaultIdleTimeout = 30 * time.Second
+)
+
+var archiveExtensions = []string{".tar.gz", ".tgz", ".zip"}
+
+var _ manager.Manager = (*Manager)(nil)
+
+//Manager represents an implementation of a ironman Manager that installs templates from archive URLs.
+//The archive is checksummed and extracted into a staging directory while it's downloaded, a resumed download
+//reads the partial download again followed by the missing bytes. The extracted contents are moved into the
+//templates directory only after the checksum is verified
+type Manager struct {
+	*manager.BaseManager
+	downloadsPath string
+	events        *event.Bus
+	client        *http.Client
+	retries       int
+	retryBackoff  time.Duration
+	idleTimeout   time.Duration
+}
+
+//New returns a new instance of the archive Manager
+func New(path string, templatesDirectory string, options ...Option) manager.Manager {
+	BaseManager := manager.NewBaseManager(path, templatesDirectory)
+	m := &Manager{
+		BaseManager:   BaseManager,
+		downloadsPath: filepath.Join(path, downloadsDirectory),
+		client:        defaultHTTPClient(),
+		retries:       defaultRetries,
+		retryBackoff:  defaultBackoff,
+		idleTimeout:   defaultIdleTimeout,
+	}
+
+	for _, option := range options {
+		option(m)
+	}
+	return m
+}
+
+//IsArchive returns whether a template locator points to a supported archive
+func IsArchive(location string) bool {
+	return archiveExtension(location) != ""
+}
+
+//Install installs a template from an archive url
+func (m *Manager) Install(location string) (string, error) {
+	id := templateIDFromLocation(location)
+	templatePath := m.TemplateLocation(id)
+
+	if _, err := os.Stat(templatePath); err == nil {
+		return "", errors.Errorf("failed to install template %s, %s already exists", location, id)
+	}
+
+	if err := m.install(location, templatePath); err != nil {
+		return "", errors.Wrapf(err, "failed to install template %s", location)
+	}
+	return id, nil
+}
+
+//Update updates a template downloading again the archive it was installed from
+func (m *Manager) Update(id string) error {
+	templatePath := m.TemplateLocation(id)
+
+	source, err := ioutil.ReadFile(filepath.Join(templatePath, sourceFileName))
+	if err != nil {
+		return errors.Wrapf(err, "failed to read archive source of template %s", id)
+	}
+	location := strings.TrimSpace(string(source))
+
+	//install next to the current template and swap them when everything went fine
+	updatePath := templatePath + ".update"
+	if err := os.RemoveAll(updatePath); err != nil {
+		return errors.Wrapf(err, "failed to clean update path for template %s", id)
+	}
+
+	if err := m.install(location, updatePath); err != nil {
+		return errors.Wrapf(err, "failed to update template %s", id)
+	}
+
+	if err := os.RemoveAll(templatePath); err != nil {
+		_ = os.RemoveAll(updatePath)
+		return errors.Wrapf(err, "failed to remove previous version of template %s", id)
+	}
+
+	if err := os.Rename(updatePath, templatePath); err != nil {
+		return errors.Wrapf(err, "failed to update template %s", id)
+	}
+	return nil
+}
+
+func (m *Manager) install(location string, templatePath string) error {
+	if err := os.MkdirAll(m.downloadsPath, os.ModePerm); err != nil {
+		return errors.Wrap(err, "failed to create downloads directory")
+	}
+
+	//the archive is extracted into a staging path while it's downloaded, it only reaches
+	//the templates directory once its checksum is verified
+	extractPath := templatePath + ".extract"
+	defer os.RemoveAll(extractPath)
+
+	archivePath, err := m.download(location, extractPath)
+	if err != nil {
+		return err
+	}
+
+	if err := moveExtracted(extractPath, templatePath); err != nil {
+		removeArchive(archivePath)
+		_ = os.RemoveAll(templatePath)
+		return err
+	}
+
+	if err := ioutil.WriteFile(filepath.Join(templatePath, sourceFileName), []byte(location), 0644); err != nil {
+		_ = os.RemoveAll(templatePath)
+		return errors.Wrap(err, "failed to save archive source")
+	}
+
+	//the archive is only kept while the download is incomplete
+	removeArchive(archivePath)
+	return nil
+}
+
+func archiveExtension(location string) string {
+	name := strings.ToLower(path.Base(stripQuery(location)))
+	for _, extension := range archiveExtensions {
+		if strings.HasSuffix(name, extension) {
+			return extension
+		}
+	}
+	return ""
+}
+
+func templateIDFromLocation(location string) string {
+	name := path.Base(stripQuery(location))
+	return name[:len(name)-len(archiveExtension(location))]
+}
+
+func stripQuery(location string) string {
+	if i := strings.IndexAny(location, "?#"); i >= 0 {
+		return location[:i]
+	}
+	return location
+}
@@ -0,0 +1,446 @@
+package archive
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"bytes"
+	"compress/gzip"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/ironman-project/ironman/pkg/event"
+	"github.com/ironman-project/ironman/pkg/testutils"
+)
+
+var testArchiveFiles = map[string]string{
+	"template-example-master/.ironman.yaml":                "id: template-example\n",
+	"template-example-master/generators/app/.ironman.yaml": "id: app\n",
+	"template-example-master/generators/app/README.md":     "# {{ .Values.title }}\n",
+}
+
+func tarGzArchive(t *testing.T, files map[string]string) []byte {
+	var buffer bytes.Buffer
+	gzipWriter := gzip.NewWriter(&buffer)
+	tarWriter := tar.NewWriter(gzipWriter)
+	for name, content := range files {
+		header := &tar.Header{Name: name, Mode: 0644, Size: int64(len(content)), Typeflag: tar.TypeReg}
+		if err := tarWriter.WriteHeader(header); err != nil {
+			t.Fatalf("failed to write tar header %s", err)
+		}
+		if _, err := tarWriter.Write([]byte(content)); err != nil {
+			t.Fatalf("failed to write tar entry %s", err)
+		}
+	}
+	if err := tarWriter.Close(); err != nil {
+		t.Fatalf("failed to close tar writer %s", err)
+	}
+	if err := gzipWriter.Close(); err != nil {
+		t.Fatalf("failed to close gzip writer %s", err)
+	}
+	return buffer.Bytes()
+}
+
+func zipArchive(t *testing.T, files map[string]string) []byte {
+	var buffer bytes.Buffer
+	zipWriter := zip.NewWriter(&buffer)
+	for name, content := range files {
+		w, err := zipWriter.Create(name)
+		if err != nil {
+			t.Fatalf("failed to create zip entry %s", err)
+		}
+		if _, err := w.Write([]byte(content)); err != nil {
+			t.Fatalf("failed to write zip entry %s", err)
+		}
+	}
+	if err := zipWriter.Close(); err != nil {
+		t.Fatalf("failed to close zip writer %s", err)
+	}
+	return buffer.Bytes()
+}
+
+func checksum(data []byte) string {
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
+
+type testServer struct {
+	*httptest.Server
+	mutex           sync.Mutex
+	rangeRequests   int
+	interruptFirst  bool
+	stallFirst      bool
+	wrongRangeFirst bool
+	//interruptChecksumFirst closes the connection of the first checksum request without a response
+	interruptChecksumFirst bool
+	checksumStatus         int
+	token                  string
+}
+
+//newTestServer serves the archive and its checksum, the archive checksum is used as its ETag.
+//interruptFirst cuts the first download in half, stallFirst stops sending data in the middle of the first download
+//and wrongRangeFirst answers the first range request with the wrong range, all of them force the manager to retry.
+//checksumStatus forces the checksum response status and if token is set both urls require it as a query parameter
+func newTestServer(name string, data []byte, sum string) *testServer {
+	s := &testServer{}
+	s.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		s.mutex.Lock()
+		defer s.mutex.Unlock()
+
+		if s.token != "" && r.URL.Query().Get("token") != s.token {
+			http.Error(w, "forbidden", http.StatusForbidden)
+			return
+		}
+
+		switch r.URL.Path {
+		case "/" + name + checksumExtension:
+			if s.interruptChecksumFirst {
+				s.interruptChecksumFirst = false
+				conn, _, err := w.(http.Hijacker).Hijack()
+				if err == nil {
+					conn.Close()
+				}
+				return
+			}
+			if s.checksumStatus != 0 {
+				http.Error(w, http.StatusText(s.checksumStatus), s.checksumStatus)
+				return
+			}
+			if sum == "" {
+				http.NotFound(w, r)
+				return
+			}
+			w.Write([]byte(sum + "  " + name + "\n"))
+		case "/" + name:
+			if r.Header.Get("Range") != "" {
+				s.rangeRequests++
+				if s.wrongRangeFirst {
+					s.wrongRangeFirst = false
+					w.Header().Set("Content-Range", fmt.Sprintf("bytes 0-%d/%d", len(data)-1, len(data)))
+					w.WriteHeader(http.StatusPartialContent)
+					w.Write(data)
+					return
+				}
+			}
+
+			w.Header().Set("ETag", `"`+checksum(data)+`"`)
+			if s.interruptFirst || s.stallFirst {
+				stall := s.stallFirst
+				s.interruptFirst, s.stallFirst = false, false
+				w.Header().Set("Content-Length", strconv.Itoa(len(data)))
+				w.Write(data[:len(data)/2])
+				if stall {
+					w.(http.Flusher).Flush()
+					s.mutex.Unlock()
+					select {
+					case <-r.Context().Done():
+					case <-time.After(5 * time.Second):
+					}
+					s.mutex.Lock()
+				}
+				return
+			}
+			http.ServeContent(w, r, name, time.Time{}, bytes.NewReader(data))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	return s
+}
+
+func newTestManager(home string) *Manager {
+	return New(home, "templates",
+		SetRetryBackoff(time.Millisecond),
+		SetIdleTimeout(200*time.Millisecond),
+	).(*Manager)
+}
+
+func TestManager_Install(t *testing.T) {
+	tarGz := tarGzArchive(t, testArchiveFiles)
+	zipData := zipArchive(t, testArchiveFiles)
+	etag := `"` + checksum(tarGz) + `"`
+
+	type args struct {
+		name      string
+		data      []byte
+		sum       string
+		query     string
+		partial   []byte
+		validator string
+		setUp     func(s *testServer)
+	}
+	tests := []struct {
+		name              string
+		args              args
+		wantErr           string
+		wantRangeRequests int
+	}{
+		{"install tar.gz archive", args{"template-example.tar.gz", tarGz, checksum(tarGz), "", nil, "", nil}, "", 0},
+		{"install zip archive", args{"template-example.zip", zipData, checksum(zipData), "", nil, "", nil}, "", 0},
+		{"install without published checksum", args{"template-example.tgz", tarGz, "", "", nil, "", nil}, "", 0},
+		{"resume interrupted download", args{"template-example.tar.gz", tarGz, checksum(tarGz), "", nil, "", func(s *testServer) { s.interruptFirst = true }}, "", 1},
+		{"resume stalled download", args{"template-example.tar.gz", tarGz, checksum(tarGz), "", nil, "", func(s *testServer) { s.stallFirst = true }}, "", 1},
+		{"resume previous partial download", args{"template-example.tar.gz", tarGz, checksum(tarGz), "", tarGz[:len(tarGz)/3], etag, nil}, "", 1},
+		{"restart partial download without validator", args{"template-example.tar.gz", tarGz, checksum(tarGz), "", tarGz[:len(tarGz)/3], "", nil}, "", 0},
+		{"restart partial download of a changed archive", args{"template-example.tar.gz", tarGz, checksum(tarGz), "", []byte("changed"), `"changed"`, nil}, "", 1},
+		{"restart download on unexpected content range", args{"template-example.tar.gz", tarGz, checksum(tarGz), "", tarGz[:len(tarGz)/3], etag, func(s *testServer) { s.wrongRangeFirst = true }}, "", 1},
+		{"checksum url keeps the query", args{"template-example.tar.gz", tarGz, checksum(tarGz), "?token=secret", nil, "", func(s *testServer) { s.token = "secret" }}, "", 0},
+		{"checksum with query mismatch", args{"template-example.tar.gz", tarGz, checksum([]byte("other")), "?token=secret", nil, "", func(s *testServer) { s.token = "secret" }}, "checksum mismatch", 0},
+		{"install with forbidden checksum", args{"template-example.tar.gz", tarGz, checksum([]byte("other")), "", nil, "", func(s *testServer) { s.checksumStatus = http.StatusForbidden }}, "", 0},
+		{"retry interrupted checksum request", args{"template-example.tar.gz", tarGz, checksum(tarGz), "", nil, "", func(s *testServer) { s.interruptChecksumFirst = true }}, "", 0},
+		{"checksum mismatch after interrupted checksum request", args{"template-example.tar.gz", tarGz, checksum([]byte("other")), "", nil, "", func(s *testServer) { s.interruptChecksumFirst = true }}, "checksum mismatch", 0},
+		{"checksum server error", args{"template-example.tar.gz", tarGz, checksum(tarGz), "", nil, "", func(s *testServer) { s.checksumStatus = http.StatusInternalServerError }}, "unexpected status", 0},
+		{"checksum mismatch", args{"template-example.tar.gz", tarGz, checksum([]byte("other")), "", nil, "", nil}, "checksum mismatch", 0},
+		{"invalid archive", args{"template-example.tar.gz", []byte("not an archive"), "", "", nil, "", nil}, "failed to extract archive", 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			home := testutils.CreateTempDir("ironman-archive", t)
+			defer os.RemoveAll(home)
+			testutils.CreateDir(filepath.Join(home, "templates"), t)
+
+			server := newTestServer(tt.args.name, tt.args.data, tt.args.sum)
+			defer server.Close()
+			if tt.args.setUp != nil {
+				tt.args.setUp(server)
+			}
+
+			m := newTestManager(home)
+			location := server.URL + "/" + tt.args.name + tt.args.query
+			archivePath := m.archivePath(location)
+
+			if tt.args.partial != nil {
+				testutils.CreateDir(m.downloadsPath, t)
+				if err := ioutil.WriteFile(archivePath, tt.args.partial, 0644); err != nil {
+					t.Fatalf("failed to write partial download %s", err)
+				}
+				if tt.args.validator != "" {
+					if err := ioutil.WriteFile(archivePath+validatorExtension, []byte(tt.args.validator), 0644); err != nil {
+						t.Fatalf("failed to write partial download validator %s", err)
+					}
+				}
+			}
+
+			id, err := m.Install(location)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Errorf("Manager.Install() error = %v, wantErr %s", err, tt.wantErr)
+				}
+				if testutils.FileExists(archivePath) {
+					t.Errorf("Manager.Install() archive that can't be installed should be removed")
+				}
+				templatePath := m.TemplateLocation("template-example")
+				if testutils.FileExists(templatePath) || testutils.FileExists(templatePath+".extract") {
+					t.Errorf("Manager.Install() archive that can't be installed should not be extracted")
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("Manager.Install() error = %v", err)
+			}
+
+			if id != "template-example" {
+				t.Errorf("Manager.Install() id = %s, want template-example", id)
+			}
+
+			for name, content := range testArchiveFiles {
+				path := filepath.Join(m.TemplateLocation(id), strings.TrimPrefix(name, "template-example-master/"))
+				if got := testutils.ReadFile(t, path); got != content {
+					t.Errorf("Manager.Install() file %s = %q, want %q", path, got, content)
+				}
+			}
+
+			if testutils.FileExists(archivePath) || testutils.FileExists(archivePath+validatorExtension) {
+				t.Errorf("Manager.Install() downloaded archive should be removed after the install")
+			}
+
+			if server.rangeRequests != tt.wantRangeRequests {
+				t.Errorf("Manager.Install() range requests = %d, want %d", server.rangeRequests, tt.wantRangeRequests)
+			}
+		})
+	}
+}
+
+func TestManager_InstallExtractsWhileDownloading(t *testing.T) {
+	home := testutils.CreateTempDir("ironman-archive", t)
+	defer os.RemoveAll(home)
+	testutils.CreateDir(filepath.Join(home, "templates"), t)
+
+	m := newTestManager(home)
+	data := tarGzArchive(t, testArchiveFiles)
+	extractPath := m.TemplateLocation("template-example") + ".extract"
+
+	//the last bytes of the archive are only sent once its entries were extracted
+	var extractedWhileDownloading bool
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/template-example.tar.gz" {
+			http.NotFound(w, r)
+			return
+		}
+
+		w.Header().Set("Content-Length", strconv.Itoa(len(data)))
+		w.Write(data[:len(data)-8])
+		w.(http.Flusher).Flush()
+
+		deadline := time.Now().Add(5 * time.Second)
+		for time.Now().Before(deadline) && !extractedWhileDownloading {
+			extractedWhileDownloading = true
+			for name, content := range testArchiveFiles {
+				got, err := ioutil.ReadFile(filepath.Join(extractPath, name))
+				if err != nil || string(got) != content {
+					extractedWhileDownloading = false
+				}
+			}
+			time.Sleep(10 * time.Millisecond)
+		}
+		w.Write(data[len(data)-8:])
+	}))
+	defer server.Close()
+
+	m.idleTimeout = 10 * time.Second
+	if _, err := m.Install(server.URL + "/template-example.tar.gz"); err != nil {
+		t.Fatalf("Manager.Install() error = %v", err)
+	}
+
+	if !extractedWhileDownloading {
+		t.Errorf("Manager.Install() archive entries should be extracted while the archive is downloaded")
+	}
+
+	if testutils.FileExists(extractPath) {
+		t.Errorf("Manager.Install() extraction path %s should be removed after the install", extractPath)
+	}
+}
+
+func TestManager_InstallEvents(t *testing.T) {
+	home := testutils.CreateTempDir("ironman-archive", t)
+	defer os.RemoveAll(home)
+	testutils.CreateDir(filepath.Join(home, "templates"), t)
+
+	data := tarGzArchive(t, testArchiveFiles)
+	server := newTestServer("template-example.tar.gz", data, "")
+	defer server.Close()
+	server.interruptFirst = true
+
+	var got []event.Type
+	bus := event.NewBus()
+	bus.Subscribe(func(e event.Event) {
+		got = append(got, e.Type)
+	})
+
+	m := New(home, "templates", SetEventBus(bus), SetRetryBackoff(time.Millisecond))
+	if _, err := m.Install(server.URL + "/template-example.tar.gz"); err != nil {
+		t.Fatalf("Manager.Install() error = %v", err)
+	}
+
+	if want := []event.Type{event.TypeChecksumSkipped, event.TypeDownloadRetried}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Manager.Install() events = %v, want %v", got, want)
+	}
+}
+
+func Test_checksumURL(t *testing.T) {
+	tests := []struct {
+		location string
+		want     string
+	}{
+		{"https://example.com/template.tar.gz", "https://example.com/template.tar.gz.sha256"},
+		{"https://example.com/template.tar.gz?token=abc&expires=1", "https://example.com/template.tar.gz.sha256?token=abc&expires=1"},
+		{"https://example.com/my%2Ftemplate.zip#readme", "https://example.com/my%2Ftemplate.zip.sha256"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.location, func(t *testing.T) {
+			got, err := checksumURL(tt.location)
+			if err != nil {
+				t.Fatalf("checksumURL() error = %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("checksumURL() = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestManager_backoff(t *testing.T) {
+	m := &Manager{retryBackoff: time.Second}
+	tests := []struct {
+		attempt int
+		want    time.Duration
+	}{
+		{1, time.Second},
+		{2, 2 * time.Second},
+		{3, 4 * time.Second},
+		{10, maxRetryBackoff},
+	}
+	for _, tt := range tests {
+		t.Run(strconv.Itoa(tt.attempt), func(t *testing.T) {
+			if got := m.backoff(tt.attempt); got != tt.want {
+				t.Errorf("Manager.backoff() = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestManager_Update(t *testing.T) {
+	home := testutils.CreateTempDir("ironman-archive", t)
+	defer os.RemoveAll(home)
+	testutils.CreateDir(filepath.Join(home, "templates"), t)
+
+	files := map[string]string{"template-example/.ironman.yaml": "id: template-example\n"}
+	data := tarGzArchive(t, files)
+	server := newTestServer("template-example.tar.gz", data, checksum(data))
+	defer server.Close()
+
+	m := New(home, "templates")
+	id, err := m.Install(server.URL + "/template-example.tar.gz")
+	if err != nil {
+		t.Fatalf("Manager.Update() failed to install template %s", err)
+	}
+
+	//a file that is not in the archive anymore should be gone after the update
+	stalePath := filepath.Join(m.TemplateLocation(id), "stale")
+	if err := ioutil.WriteFile(stalePath, []byte("stale"), 0644); err != nil {
+		t.Fatalf("Manager.Update() failed to write stale file %s", err)
+	}
+
+	if err := m.Update(id); err != nil {
+		t.Fatalf("Manager.Update() error = %v", err)
+	}
+
+	if testutils.FileExists(stalePath) {
+		t.Errorf("Manager.Update() stale file %s should not exist", stalePath)
+	}
+
+	if !testutils.FileExists(filepath.Join(m.TemplateLocation(id), ".ironman.yaml")) {
+		t.Errorf("Manager.Update() template metadata should exist")
+	}
+}
+
+func TestIsArchive(t *testing.T) {
+	tests := []struct {
+		location string
+		want     bool
+	}{
+		{"https://example.com/template.tar.gz", true},
+		{"https://example.com/template.tgz", true},
+		{"https://example.com/template.zip?token=abc", true},
+		{"https://github.com/ironman-project/template-example.git", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.location, func(t *testing.T) {
+			if got := IsArchive(tt.location); got != tt.want {
+				t.Errorf("IsArchive() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
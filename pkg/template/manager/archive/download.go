@@ -0,0 +1,437 @@
+package archive
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"hash"
+	"io"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/ironman-project/ironman/pkg/event"
+	"github.com/pkg/errors"
+)
+
+const (
+	checksumExtension  = ".sha256"
+	validatorExtension = ".validator"
+	maxRetryBackoff    = 30 * time.Second
+)
+
+//defaultHTTPClient returns a client that gives up on unreachable or unresponsive servers,
+//there is no overall timeout since big archives can take a long time to download
+func defaultHTTPClient() *http.Client {
+	return &http.Client{
+		Transport: &http.Transport{
+			Proxy: http.ProxyFromEnvironment,
+			DialContext: (&net.Dialer{
+				Timeout:   30 * time.Second,
+				KeepAlive: 30 * time.Second,
+			}).DialContext,
+			TLSHandshakeTimeout:   10 * time.Second,
+			ResponseHeaderTimeout: 30 * time.Second,
+			IdleConnTimeout:       90 * time.Second,
+		},
+	}
+}
+
+//archiveDownload represents an archive being downloaded into the downloads directory and extracted
+//into the extraction path while it's downloaded
+type archiveDownload struct {
+	path        string
+	extension   string
+	extractPath string
+	//size the number of archive bytes already downloaded
+	size int64
+	//hash the checksum of the archive bytes read by the last download attempt
+	hash hash.Hash
+}
+
+//reset discards the partial download
+func (d *archiveDownload) reset() {
+	removeArchive(d.path)
+	d.size = 0
+}
+
+//downloadWriter writes the downloaded bytes into the archive file keeping count of the downloaded bytes
+type downloadWriter struct {
+	file     *os.File
+	download *archiveDownload
+}
+
+func (w *downloadWriter) Write(p []byte) (int, error) {
+	n, err := w.file.Write(p)
+	w.download.size += int64(n)
+	return n, err
+}
+
+//bodyReader keeps the error reading the response body. A body error means the download was interrupted,
+//any other error reading the archive means the archive is not valid
+type bodyReader struct {
+	reader io.Reader
+	err    error
+}
+
+func (r *bodyReader) Read(p []byte) (int, error) {
+	n, err := r.reader.Read(p)
+	if err != nil && err != io.EOF {
+		r.err = err
+	}
+	return n, err
+}
+
+//download downloads the archive resuming a previous partial download if there is one and extracts it into
+//the extraction path. Returns the path of the downloaded archive after verifying its published checksum
+func (m *Manager) download(location string, extractPath string) (string, error) {
+	checksum, err := m.publishedChecksum(location)
+	if err != nil {
+		return "", err
+	}
+
+	archivePath := m.archivePath(location)
+	d := resumeArchive(archivePath, archiveExtension(location), extractPath)
+
+	err = m.retry(location, func() (bool, error) {
+		return m.downloadRange(location, d)
+	})
+	if err != nil {
+		return "", errors.Wrapf(err, "failed to download archive %s", location)
+	}
+
+	if checksum != "" {
+		sum := hex.EncodeToString(d.hash.Sum(nil))
+		if sum != checksum {
+			//the downloaded bytes can't be trusted, don't resume from them
+			removeArchive(archivePath)
+			_ = os.RemoveAll(extractPath)
+			return "", errors.Errorf("checksum mismatch for archive %s expected %s got %s", location, checksum, sum)
+		}
+	}
+
+	//zip archives index their entries at the end, they can only be extracted once downloaded
+	if d.extension == ".zip" {
+		if err := extractZip(archivePath, extractPath); err != nil {
+			removeArchive(archivePath)
+			return "", errors.Wrapf(err, "failed to extract archive %s", location)
+		}
+	}
+	return archivePath, nil
+}
+
+//resumeArchive returns a download continuing a previous partial download, the partial download is only
+//kept if the validator of the response it came from was saved, otherwise the download starts again
+func resumeArchive(archivePath string, extension string, extractPath string) *archiveDownload {
+	d := &archiveDownload{path: archivePath, extension: extension, extractPath: extractPath, hash: sha256.New()}
+
+	if _, err := os.Stat(archivePath + validatorExtension); err != nil {
+		d.reset()
+		return d
+	}
+
+	info, err := os.Stat(archivePath)
+	if err != nil {
+		d.reset()
+		return d
+	}
+	d.size = info.Size()
+	return d
+}
+
+//downloadRange downloads the remaining bytes of the archive while the whole archive, the partial download followed
+//by the response body, is checksummed and extracted. Returns whether the download is done or it should be retried.
+//A resumed range is only accepted if the archive didn't change since the previous response (If-Range)
+func (m *Manager) downloadRange(location string, d *archiveDownload) (bool, error) {
+	header := http.Header{}
+	if d.size > 0 {
+		validator, err := ioutil.ReadFile(d.path + validatorExtension)
+		if err != nil {
+			d.reset()
+		} else {
+			header.Set("Range", fmt.Sprintf("bytes=%d-", d.size))
+			header.Set("If-Range", string(validator))
+		}
+	}
+
+	resp, err := m.get(location, header)
+	if err != nil {
+		return false, err
+	}
+	defer resp.Body.Close()
+
+	body := &bodyReader{reader: resp.Body}
+	flags := os.O_CREATE | os.O_WRONLY
+	switch resp.StatusCode {
+	case http.StatusPartialContent:
+		if !strings.HasPrefix(resp.Header.Get("Content-Range"), fmt.Sprintf("bytes %d-", d.size)) {
+			//the bytes don't continue the partial download, asking for the same range again won't help
+			d.reset()
+			return false, errors.Errorf("unexpected content range %s", resp.Header.Get("Content-Range"))
+		}
+		flags |= os.O_APPEND
+	case http.StatusOK:
+		//the server doesn't support ranges or the archive changed, start again
+		d.reset()
+		flags |= os.O_TRUNC
+	case http.StatusRequestedRangeNotSatisfiable:
+		contentRange := resp.Header.Get("Content-Range")
+		if contentRange != "" && contentRange != fmt.Sprintf("bytes */%d", d.size) {
+			d.reset()
+			return false, errors.Errorf("unexpected content range %s", contentRange)
+		}
+		//the previous download already got all the bytes
+		body = nil
+	default:
+		return true, errors.Errorf("unexpected status %s", resp.Status)
+	}
+
+	var readers []io.Reader
+	if d.size > 0 {
+		partial, err := os.Open(d.path)
+		if err != nil {
+			return true, errors.Wrapf(err, "failed to open partial download %s", d.path)
+		}
+		defer partial.Close()
+		readers = append(readers, io.LimitReader(partial, d.size))
+	}
+
+	if body != nil {
+		if err := saveValidator(d.path, resp); err != nil {
+			return true, err
+		}
+
+		archiveFile, err := os.OpenFile(d.path, flags, 0644)
+		if err != nil {
+			return true, errors.Wrapf(err, "failed to open archive file %s", d.path)
+		}
+		defer archiveFile.Close()
+		readers = append(readers, io.TeeReader(body, &downloadWriter{file: archiveFile, download: d}))
+	}
+
+	d.hash.Reset()
+	archive := io.TeeReader(io.MultiReader(readers...), d.hash)
+
+	err = extractStream(archive, d.extension, d.extractPath)
+	if err == nil {
+		//the archive can have trailing bytes after the last entry, they are part of the checksum
+		_, err = io.Copy(ioutil.Discard, archive)
+	}
+
+	if body != nil && body.err != nil {
+		return false, body.err
+	}
+
+	if err != nil {
+		d.reset()
+		return true, errors.Wrapf(err, "failed to extract archive %s", location)
+	}
+	return true, nil
+}
+
+//saveValidator saves the response validator next to the archive so the download can be resumed later.
+//Weak ETags can't be used with If-Range, without a validator the download can't be resumed safely
+func saveValidator(archivePath string, resp *http.Response) error {
+	validator := resp.Header.Get("ETag")
+	if validator == "" || strings.HasPrefix(validator, "W/") {
+		validator = resp.Header.Get("Last-Modified")
+	}
+
+	validatorPath := archivePath + validatorExtension
+	if validator == "" {
+		_ = os.Remove(validatorPath)
+		return nil
+	}
+
+	if err := ioutil.WriteFile(validatorPath, []byte(validator), 0644); err != nil {
+		return errors.Wrapf(err, "failed to save download validator %s", validatorPath)
+	}
+	return nil
+}
+
+//publishedChecksum returns the checksum published alongside the archive, empty if there is none or it
+//is not accessible. Any other failure is an error since skipping the verification on e.g a server error
+//would silently install an unverified archive
+func (m *Manager) publishedChecksum(location string) (string, error) {
+	checksumLocation, err := checksumURL(location)
+	if err != nil {
+		return "", err
+	}
+
+	var checksum string
+	err = m.retry(checksumLocation, func() (bool, error) {
+		var done bool
+		var err error
+		checksum, done, err = m.fetchChecksum(location, checksumLocation)
+		return done, err
+	})
+	if err != nil {
+		return "", errors.Wrapf(err, "failed to get archive checksum for %s", location)
+	}
+	return checksum, nil
+}
+
+//fetchChecksum gets the published checksum, returns whether the request is done or it should be retried
+func (m *Manager) fetchChecksum(location string, checksumLocation string) (string, bool, error) {
+	resp, err := m.get(checksumLocation, nil)
+	if err != nil {
+		return "", false, err
+	}
+	defer resp.Body.Close()
+
+	switch resp.StatusCode {
+	case http.StatusOK:
+	case http.StatusNotFound:
+		m.events.Publish(event.Event{Type: event.TypeChecksumSkipped, Source: location, Messages: []string{"no published checksum found"}})
+		return "", true, nil
+	case http.StatusUnauthorized, http.StatusForbidden:
+		//signed archive urls are usually only valid for the archive itself
+		m.events.Publish(event.Event{Type: event.TypeChecksumSkipped, Source: location, Messages: []string{"published checksum is not accessible (" + resp.Status + ")"}})
+		return "", true, nil
+	default:
+		//server errors are usually temporary
+		return "", resp.StatusCode < http.StatusInternalServerError, errors.Errorf("unexpected status %s", resp.Status)
+	}
+
+	data, err := ioutil.ReadAll(io.LimitReader(resp.Body, 1024))
+	if err != nil {
+		return "", false, err
+	}
+
+	//sha256sum format <checksum>  <file name>
+	fields := strings.Fields(string(data))
+	if len(fields) == 0 {
+		return "", true, errors.New("empty archive checksum")
+	}
+	return strings.ToLower(fields[0]), true, nil
+}
+
+//checksumURL returns the url of the checksum published alongside the archive keeping the archive url query e.g access tokens
+func checksumURL(location string) (string, error) {
+	u, err := url.Parse(location)
+	if err != nil {
+		return "", errors.Wrapf(err, "invalid archive url %s", location)
+	}
+
+	u.Path += checksumExtension
+	if u.RawPath != "" {
+		u.RawPath += checksumExtension
+	}
+	u.Fragment = ""
+	return u.String(), nil
+}
+
+//get sends a get request, reading the response body fails if no data is received for longer than the idle timeout
+func (m *Manager) get(location string, header http.Header) (*http.Response, error) {
+	ctx, cancel := context.WithCancel(context.Background())
+	req, err := http.NewRequest(http.MethodGet, location, nil)
+	if err != nil {
+		cancel()
+		return nil, errors.Wrapf(err, "invalid url %s", location)
+	}
+	req = req.WithContext(ctx)
+
+	for key, values := range header {
+		req.Header[key] = values
+	}
+
+	resp, err := m.client.Do(req)
+	if err != nil {
+		cancel()
+		return nil, err
+	}
+
+	resp.Body = &idleTimeoutBody{ReadCloser: resp.Body, timeout: m.idleTimeout, cancel: cancel}
+	return resp, nil
+}
+
+//idleTimeoutBody cancels the request when a read blocks for longer than the timeout
+type idleTimeoutBody struct {
+	io.ReadCloser
+	timeout time.Duration
+	timer   *time.Timer
+	cancel  context.CancelFunc
+	stalled int32
+}
+
+func (b *idleTimeoutBody) Read(p []byte) (int, error) {
+	if b.timeout <= 0 {
+		return b.ReadCloser.Read(p)
+	}
+
+	if b.timer == nil {
+		b.timer = time.AfterFunc(b.timeout, b.stall)
+	} else {
+		b.timer.Reset(b.timeout)
+	}
+	n, err := b.ReadCloser.Read(p)
+	b.timer.Stop()
+	if err != nil && atomic.LoadInt32(&b.stalled) == 1 {
+		return n, errors.Errorf("no data received for %s", b.timeout)
+	}
+	return n, err
+}
+
+func (b *idleTimeoutBody) Close() error {
+	if b.timer != nil {
+		b.timer.Stop()
+	}
+	b.cancel()
+	return b.ReadCloser.Close()
+}
+
+func (b *idleTimeoutBody) stall() {
+	atomic.StoreInt32(&b.stalled, 1)
+	b.cancel()
+}
+
+//retry calls attempt until it's done waiting longer before every retry, returns the last attempt error
+func (m *Manager) retry(location string, attempt func() (bool, error)) error {
+	var err error
+	for n := 0; n <= m.retries; n++ {
+		if n > 0 {
+			m.events.Publish(event.Event{Type: event.TypeDownloadRetried, Source: location, Err: err})
+			time.Sleep(m.backoff(n))
+		}
+
+		var done bool
+		done, err = attempt()
+		if done {
+			return err
+		}
+	}
+	return err
+}
+
+//backoff returns how long to wait before a retry attempt, it doubles on every attempt
+func (m *Manager) backoff(attempt int) time.Duration {
+	backoff := m.retryBackoff
+	for i := 1; i < attempt && backoff < maxRetryBackoff; i++ {
+		backoff *= 2
+	}
+	if backoff > maxRetryBackoff {
+		return maxRetryBackoff
+	}
+	return backoff
+}
+
+//archivePath returns the download path of the archive, unique per location so partial downloads can be resumed
+func (m *Manager) archivePath(location string) string {
+	sum := sha256.Sum256([]byte(location))
+	name := hex.EncodeToString(sum[:8]) + "-" + path.Base(stripQuery(location))
+	return filepath.Join(m.downloadsPath, name)
+}
+
+//removeArchive removes a downloaded archive and its validator
+func removeArchive(archivePath string) {
+	_ = os.Remove(archivePath)
+	_ = os.Remove(archivePath + validatorExtension)
+}
@@ -0,0 +1,166 @@
+package archive
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"compress/gzip"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+//extractStream extracts the archive entries into the extraction path while the archive is read.
+//Zip archives index their entries at the end so they are extracted once downloaded instead
+func extractStream(reader io.Reader, extension string, extractPath string) error {
+	if extension == ".zip" {
+		return nil
+	}
+	return extractTarGz(reader, extractPath)
+}
+
+//moveExtracted moves the extracted archive into the template path. If all the archive entries are inside
+//a single root directory e.g template-example-master/ its contents become the template root
+func moveExtracted(extractPath string, templatePath string) error {
+	rootPath, err := archiveRoot(extractPath)
+	if err != nil {
+		return err
+	}
+
+	if err := os.Rename(rootPath, templatePath); err != nil {
+		return errors.Wrapf(err, "failed to move extracted archive to %s", templatePath)
+	}
+	return nil
+}
+
+//cleanExtractPath removes the entries of a previous extraction attempt
+func cleanExtractPath(extractPath string) error {
+	if err := os.RemoveAll(extractPath); err != nil {
+		return errors.Wrapf(err, "failed to clean extraction path %s", extractPath)
+	}
+	return os.MkdirAll(extractPath, os.ModePerm)
+}
+
+func extractTarGz(reader io.Reader, extractPath string) error {
+	if err := cleanExtractPath(extractPath); err != nil {
+		return err
+	}
+
+	gzipReader, err := gzip.NewReader(reader)
+	if err != nil {
+		return err
+	}
+	defer gzipReader.Close()
+
+	tarReader := tar.NewReader(gzipReader)
+	for {
+		header, err := tarReader.Next()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		target, err := entryPath(extractPath, header.Name)
+		if err != nil {
+			return err
+		}
+
+		switch header.Typeflag {
+		case tar.TypeDir:
+			err = os.MkdirAll(target, os.ModePerm)
+		case tar.TypeReg:
+			err = writeEntry(target, header.FileInfo().Mode(), tarReader)
+		default:
+			//links and special files are not part of a template
+			continue
+		}
+
+		if err != nil {
+			return err
+		}
+	}
+}
+
+func extractZip(archivePath string, extractPath string) error {
+	if err := cleanExtractPath(extractPath); err != nil {
+		return err
+	}
+
+	zipReader, err := zip.OpenReader(archivePath)
+	if err != nil {
+		return err
+	}
+	defer zipReader.Close()
+
+	for _, file := range zipReader.File {
+		target, err := entryPath(extractPath, file.Name)
+		if err != nil {
+			return err
+		}
+
+		if file.FileInfo().IsDir() {
+			if err := os.MkdirAll(target, os.ModePerm); err != nil {
+				return err
+			}
+			continue
+		}
+
+		if !file.Mode().IsRegular() {
+			continue
+		}
+
+		reader, err := file.Open()
+		if err != nil {
+			return err
+		}
+
+		err = writeEntry(target, file.Mode(), reader)
+		reader.Close()
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+//entryPath returns the extraction path of an archive entry, the entry can't be outside the extraction path
+func entryPath(extractPath string, name string) (string, error) {
+	entryPath := filepath.Join(extractPath, name)
+	if entryPath != extractPath && !strings.HasPrefix(entryPath, extractPath+string(os.PathSeparator)) {
+		return "", errors.Errorf("invalid archive entry %s", name)
+	}
+	return entryPath, nil
+}
+
+func writeEntry(entryPath string, mode os.FileMode, reader io.Reader) error {
+	if err := os.MkdirAll(filepath.Dir(entryPath), os.ModePerm); err != nil {
+		return err
+	}
+
+	f, err := os.OpenFile(entryPath, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, mode.Perm())
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	_, err = io.Copy(f, reader)
+	return err
+}
+
+//archiveRoot returns the single root directory of the extracted archive or the extraction path itself
+func archiveRoot(extractPath string) (string, error) {
+	files, err := ioutil.ReadDir(extractPath)
+	if err != nil {
+		return "", errors.Wrapf(err, "failed to read extracted archive %s", extractPath)
+	}
+
+	if len(files) == 1 && files[0].IsDir() {
+		return filepath.Join(extractPath, files[0].Name()), nil
+	}
+	return extractPath, nil
+}
@@ -0,0 +1,46 @@
+package archive
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/ironman-project/ironman/pkg/event"
+)
+
+//Option represents an archive manager setter
+type Option func(manager *Manager)
+
+//SetEventBus sets the bus where the manager publishes the download events
+func SetEventBus(events *event.Bus) Option {
+	return func(manager *Manager) {
+		manager.events = events
+	}
+}
+
+//SetHTTPClient sets the http client used to download the archives
+func SetHTTPClient(client *http.Client) Option {
+	return func(manager *Manager) {
+		manager.client = client
+	}
+}
+
+//SetRetries sets how many times a download is resumed after a failure
+func SetRetries(retries int) Option {
+	return func(manager *Manager) {
+		manager.retries = retries
+	}
+}
+
+//SetRetryBackoff sets how long to wait before the first retry, the wait doubles on every retry
+func SetRetryBackoff(backoff time.Duration) Option {
+	return func(manager *Manager) {
+		manager.retryBackoff = backoff
+	}
+}
+
+//SetIdleTimeout sets how long a download can go without receiving data before it's interrupted, zero disables it
+func SetIdleTimeout(timeout time.Duration) Option {
+	return func(manager *Manager) {
+		manager.idleTimeout = timeout
+	}
+}
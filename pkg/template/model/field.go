@@ -0,0 +1,168 @@
+package model
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"unicode/utf8"
+
+	"github.com/pkg/errors"
+)
+
+//FieldValidation validation rules for a generator field value
+type FieldValidation struct {
+	Pattern   string   `json:"pattern,omitempty" yaml:"pattern,omitempty"`
+	MinLength *int     `json:"minLength,omitempty" yaml:"minLength,omitempty"`
+	MaxLength *int     `json:"maxLength,omitempty" yaml:"maxLength,omitempty"`
+	Min       *float64 `json:"min,omitempty" yaml:"min,omitempty"`
+	Max       *float64 `json:"max,omitempty" yaml:"max,omitempty"`
+	Message   string   `json:"message,omitempty" yaml:"message,omitempty"`
+}
+
+//Field represents a value a generator expects, the ID is the dotted path of the value e.g app.name
+type Field struct {
+	ID          string           `json:"id" yaml:"id"`
+	Description string           `json:"description,omitempty" yaml:"description,omitempty"`
+	Default     interface{}      `json:"default,omitempty" yaml:"default,omitempty"`
+	Validation  *FieldValidation `json:"validation,omitempty" yaml:"validation,omitempty"`
+	pattern     *regexp.Regexp
+}
+
+//FieldError represents a field value that didn't pass the field validation rules
+type FieldError struct {
+	Path    string
+	Value   interface{}
+	Message string
+}
+
+func (e *FieldError) Error() string {
+	return fmt.Sprintf("invalid value '%v' for field '%s': %s", e.Value, e.Path, e.Message)
+}
+
+//Check checks that the field validation rules are valid themselves e.g the pattern compiles and min is not greater than max,
+//and that the field default value passes them
+func (f *Field) Check() error {
+	if f.ID == "" {
+		return errors.New("a field id cannot be empty")
+	}
+
+	v := f.Validation
+	if v == nil {
+		return nil
+	}
+
+	if _, err := f.compiledPattern(); err != nil {
+		return err
+	}
+
+	if (v.MinLength != nil && *v.MinLength < 0) || (v.MaxLength != nil && *v.MaxLength < 0) {
+		return errors.Errorf("field %s lengths cannot be negative", f.ID)
+	}
+
+	if v.MinLength != nil && v.MaxLength != nil && *v.MinLength > *v.MaxLength {
+		return errors.Errorf("field %s minLength %d is greater than maxLength %d", f.ID, *v.MinLength, *v.MaxLength)
+	}
+
+	if v.Min != nil && v.Max != nil && *v.Min > *v.Max {
+		return errors.Errorf("field %s min %v is greater than max %v", f.ID, *v.Min, *v.Max)
+	}
+
+	if f.Default != nil {
+		if err := f.Validate(f.Default); err != nil {
+			if fieldErr, ok := err.(*FieldError); ok {
+				return errors.Errorf("field %s default value '%v' %s", f.ID, f.Default, fieldErr.Message)
+			}
+			return err
+		}
+	}
+	return nil
+}
+
+//compiledPattern compiles the validation pattern only once
+func (f *Field) compiledPattern() (*regexp.Regexp, error) {
+	if f.pattern == nil {
+		re, err := regexp.Compile(f.Validation.Pattern)
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid validation pattern for field %s", f.ID)
+		}
+		f.pattern = re
+	}
+	return f.pattern, nil
+}
+
+//Validate validates a value against the field validation rules, returns a *FieldError if the value is not valid
+//and a regular error if the field validation rules are not valid
+func (f *Field) Validate(value interface{}) error {
+	v := f.Validation
+	if v == nil {
+		return nil
+	}
+
+	if v.Pattern != "" || v.MinLength != nil || v.MaxLength != nil {
+		str := fmt.Sprint(value)
+
+		if v.Pattern != "" {
+			re, err := f.compiledPattern()
+			if err != nil {
+				return err
+			}
+			if !re.MatchString(str) {
+				return f.fieldError(value, "should match pattern %s", v.Pattern)
+			}
+		}
+
+		length := utf8.RuneCountInString(str)
+		if v.MinLength != nil && length < *v.MinLength {
+			return f.fieldError(value, "should have at least %d characters", *v.MinLength)
+		}
+
+		if v.MaxLength != nil && length > *v.MaxLength {
+			return f.fieldError(value, "should have at most %d characters", *v.MaxLength)
+		}
+	}
+
+	if v.Min != nil || v.Max != nil {
+		number, ok := toFloat(value)
+		if !ok {
+			return f.fieldError(value, "should be a number")
+		}
+
+		if v.Min != nil && number < *v.Min {
+			return f.fieldError(value, "should be greater than or equal to %v", *v.Min)
+		}
+
+		if v.Max != nil && number > *v.Max {
+			return f.fieldError(value, "should be less than or equal to %v", *v.Max)
+		}
+	}
+
+	return nil
+}
+
+func (f *Field) fieldError(value interface{}, format string, args ...interface{}) error {
+	message := f.Validation.Message
+	if message == "" {
+		message = fmt.Sprintf(format, args...)
+	}
+	return &FieldError{Path: f.ID, Value: value, Message: message}
+}
+
+func toFloat(value interface{}) (float64, bool) {
+	switch n := value.(type) {
+	case int:
+		return float64(n), true
+	case int32:
+		return float64(n), true
+	case int64:
+		return float64(n), true
+	case float32:
+		return float64(n), true
+	case float64:
+		return n, true
+	case string:
+		f, err := strconv.ParseFloat(n, 64)
+		return f, err == nil
+	default:
+		return 0, false
+	}
+}
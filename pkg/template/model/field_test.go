@@ -0,0 +1,122 @@
+package model
+
+import (
+	"testing"
+)
+
+func intPtr(i int) *int {
+	return &i
+}
+
+func floatPtr(f float64) *float64 {
+	return &f
+}
+
+func TestField_Validate(t *testing.T) {
+	tests := []struct {
+		name      string
+		field     Field
+		value     interface{}
+		wantErr   bool
+		wantError string
+	}{
+		{
+			"no validation rules",
+			Field{ID: "name"},
+			"anything",
+			false,
+			"",
+		},
+		{
+			"pattern match",
+			Field{ID: "name", Validation: &FieldValidation{Pattern: "^[a-z]+$"}},
+			"ironman",
+			false,
+			"",
+		},
+		{
+			"pattern mismatch",
+			Field{ID: "app.name", Validation: &FieldValidation{Pattern: "^[a-z]+$"}},
+			"Iron Man",
+			true,
+			"invalid value 'Iron Man' for field 'app.name': should match pattern ^[a-z]+$",
+		},
+		{
+			"min length",
+			Field{ID: "name", Validation: &FieldValidation{MinLength: intPtr(3)}},
+			"ab",
+			true,
+			"invalid value 'ab' for field 'name': should have at least 3 characters",
+		},
+		{
+			"max length",
+			Field{ID: "name", Validation: &FieldValidation{MaxLength: intPtr(3)}},
+			"abcd",
+			true,
+			"invalid value 'abcd' for field 'name': should have at most 3 characters",
+		},
+		{
+			"numeric range",
+			Field{ID: "port", Validation: &FieldValidation{Min: floatPtr(1), Max: floatPtr(65535)}},
+			int64(8080),
+			false,
+			"",
+		},
+		{
+			"numeric range from string",
+			Field{ID: "port", Validation: &FieldValidation{Min: floatPtr(1), Max: floatPtr(65535)}},
+			"70000",
+			true,
+			"invalid value '70000' for field 'port': should be less than or equal to 65535",
+		},
+		{
+			"not a number",
+			Field{ID: "port", Validation: &FieldValidation{Min: floatPtr(1)}},
+			"http",
+			true,
+			"invalid value 'http' for field 'port': should be a number",
+		},
+		{
+			"custom message",
+			Field{ID: "port", Validation: &FieldValidation{Min: floatPtr(1024), Message: "ports below 1024 are reserved"}},
+			80,
+			true,
+			"invalid value '80' for field 'port': ports below 1024 are reserved",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.field.Validate(tt.value)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Field.Validate() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if err != nil && err.Error() != tt.wantError {
+				t.Errorf("Field.Validate() error = %q, want %q", err.Error(), tt.wantError)
+			}
+		})
+	}
+}
+
+func TestField_Check(t *testing.T) {
+	tests := []struct {
+		name    string
+		field   Field
+		wantErr bool
+	}{
+		{"valid rules", Field{ID: "name", Validation: &FieldValidation{Pattern: "^[a-z]+$", MinLength: intPtr(1), MaxLength: intPtr(3)}}, false},
+		{"no rules", Field{ID: "name"}, false},
+		{"empty id", Field{}, true},
+		{"invalid pattern", Field{ID: "name", Validation: &FieldValidation{Pattern: "^[a-z"}}, true},
+		{"negative length", Field{ID: "name", Validation: &FieldValidation{MinLength: intPtr(-1)}}, true},
+		{"min length greater than max length", Field{ID: "name", Validation: &FieldValidation{MinLength: intPtr(3), MaxLength: intPtr(1)}}, true},
+		{"min greater than max", Field{ID: "port", Validation: &FieldValidation{Min: floatPtr(10), Max: floatPtr(1)}}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.field.Check(); (err != nil) != tt.wantErr {
+				t.Errorf("Field.Check() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -25,6 +25,17 @@ type Generator struct {
 	DirectoryName   string          `json:"directoryName" yaml:"-"`
 	FileTypeOptions FileTypeOptions `json:"fileTypeOptions,omitempty" yaml:"fileTypeOptions,omitempty"`
 	Hooks           *GeneratorHooks `json:"hooks,omitempty" yaml:"hooks,omitempty"`
+	Fields          []*Field        `json:"fields,omitempty" yaml:"fields,omitempty"`
+}
+
+//Field returns a field by ID, nil if not exists
+func (g *Generator) Field(ID string) *Field {
+	for _, field := range g.Fields {
+		if field.ID == ID {
+			return field
+		}
+	}
+	return nil
 }
 
 //Type Simple type serialization for generator model
@@ -54,6 +54,16 @@ func Test_fsReader_Read(t *testing.T) {
 						Name:          "File Generator",
 						Description:   "This is a test generator",
 						DirectoryName: "controller",
+						Fields: []*Field{
+							&Field{
+								ID:          "controller.name",
+								Description: "The controller name",
+								Validation: &FieldValidation{
+									Pattern: "^[A-Z][a-zA-Z]*$",
+									Message: "should be a capitalized name",
+								},
+							},
+						},
 					},
 				},
 			},
@@ -0,0 +1,29 @@
+package validator
+
+import (
+	"fmt"
+
+	"github.com/ironman-project/ironman/pkg/template/model"
+)
+
+var _ Validator = (*fieldsValidator)(nil)
+
+type fieldsValidator struct {
+}
+
+//NewFieldsValidator returns a validator that checks the generators fields validation rules
+func NewFieldsValidator() Validator {
+	return &fieldsValidator{}
+}
+
+func (v *fieldsValidator) Validate(templateModel *model.Template) (bool, []string, error) {
+	var errors []string
+	for _, generator := range templateModel.Generators {
+		for _, field := range generator.Fields {
+			if err := field.Check(); err != nil {
+				errors = append(errors, fmt.Sprintf("generator %s: %s", generator.ID, err))
+			}
+		}
+	}
+	return len(errors) == 0, errors, nil
+}
@@ -0,0 +1,78 @@
+package validator
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/ironman-project/ironman/pkg/template/model"
+)
+
+func Test_fieldsValidator_Validate(t *testing.T) {
+	min, max := 10.0, 1.0
+	minPort := 1024.0
+	tests := []struct {
+		name       string
+		fields     []*model.Field
+		wantValid  bool
+		wantErrors []string
+	}{
+		{
+			"valid fields",
+			[]*model.Field{
+				{ID: "app.name", Validation: &model.FieldValidation{Pattern: "^[a-z]+$"}},
+				{ID: "app.port"},
+			},
+			true,
+			nil,
+		},
+		{
+			"invalid pattern",
+			[]*model.Field{
+				{ID: "app.name", Validation: &model.FieldValidation{Pattern: "^[a-z"}},
+			},
+			false,
+			[]string{"generator app: invalid validation pattern for field app.name: error parsing regexp: missing closing ]: `[a-z`"},
+		},
+		{
+			"min greater than max",
+			[]*model.Field{
+				{ID: "app.port", Validation: &model.FieldValidation{Min: &min, Max: &max}},
+			},
+			false,
+			[]string{"generator app: field app.port min 10 is greater than max 1"},
+		},
+		{
+			"valid default",
+			[]*model.Field{
+				{ID: "app.port", Default: 8080, Validation: &model.FieldValidation{Min: &minPort}},
+			},
+			true,
+			nil,
+		},
+		{
+			"default not passing the rules",
+			[]*model.Field{
+				{ID: "app.port", Default: 80, Validation: &model.FieldValidation{Min: &minPort}},
+			},
+			false,
+			[]string{"generator app: field app.port default value '80' should be greater than or equal to 1024"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			templateModel := &model.Template{
+				Generators: []*model.Generator{{ID: "app", Fields: tt.fields}},
+			}
+			valid, errors, err := NewFieldsValidator().Validate(templateModel)
+			if err != nil {
+				t.Fatalf("fieldsValidator.Validate() error = %v", err)
+			}
+			if valid != tt.wantValid {
+				t.Errorf("fieldsValidator.Validate() valid = %v, want %v", valid, tt.wantValid)
+			}
+			if !reflect.DeepEqual(errors, tt.wantErrors) {
+				t.Errorf("fieldsValidator.Validate() errors = %q, want %q", errors, tt.wantErrors)
+			}
+		})
+	}
+}
@@ -1,5 +1,12 @@
 package values
 
+import (
+	"fmt"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
 //Values Represents the values read from a reader
 type Values map[string]interface{}
 
@@ -7,3 +14,93 @@ type Values map[string]interface{}
 type Reader interface {
 	Read() (Values, error)
 }
+
+//Lookup returns the value on a dotted path e.g app.name, false if it doesn't exists
+func (v Values) Lookup(path string) (interface{}, bool) {
+	var current interface{} = map[string]interface{}(v)
+	for _, key := range strings.Split(path, ".") {
+		switch m := current.(type) {
+		case map[string]interface{}:
+			value, ok := m[key]
+			if !ok {
+				return nil, false
+			}
+			current = value
+		case map[interface{}]interface{}:
+			value, ok := m[key]
+			if !ok {
+				return nil, false
+			}
+			current = value
+		default:
+			return nil, false
+		}
+	}
+	return current, true
+}
+
+//Set sets the value on a dotted path e.g app.name, creating the intermediate values if they don't exist
+func (v Values) Set(path string, value interface{}) error {
+	keys := strings.Split(path, ".")
+	current := map[string]interface{}(v)
+	for _, key := range keys[:len(keys)-1] {
+		child, ok := current[key]
+		if !ok {
+			next := map[string]interface{}{}
+			current[key] = next
+			current = next
+			continue
+		}
+
+		switch next := child.(type) {
+		case map[string]interface{}:
+			current = next
+		case map[interface{}]interface{}:
+			//values decoded from yaml files, convert them to be able to keep walking the path
+			converted := map[string]interface{}{}
+			for k, val := range next {
+				converted[fmt.Sprint(k)] = val
+			}
+			current[key] = converted
+			current = converted
+		default:
+			return errors.Errorf("value %s in path %s is not a map", key, path)
+		}
+	}
+	current[keys[len(keys)-1]] = value
+	return nil
+}
+
+//Copy returns a deep copy of the values, nested maps and lists are copied so the copy can be changed safely
+func (v Values) Copy() Values {
+	if v == nil {
+		return Values{}
+	}
+	return Values(copyValue(map[string]interface{}(v)).(map[string]interface{}))
+}
+
+func copyValue(value interface{}) interface{} {
+	switch v := value.(type) {
+	case Values:
+		return v.Copy()
+	case map[string]interface{}:
+		copied := make(map[string]interface{}, len(v))
+		for key, child := range v {
+			copied[key] = copyValue(child)
+		}
+		return copied
+	case map[interface{}]interface{}:
+		copied := make(map[interface{}]interface{}, len(v))
+		for key, child := range v {
+			copied[key] = copyValue(child)
+		}
+		return copied
+	case []interface{}:
+		copied := make([]interface{}, len(v))
+		for i, item := range v {
+			copied[i] = copyValue(item)
+		}
+		return copied
+	}
+	return value
+}
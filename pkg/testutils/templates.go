@@ -0,0 +1,13 @@
+package testutils
+
+import (
+	"path/filepath"
+	"runtime"
+)
+
+//FieldsTemplatePath returns the path of the template fixture with generator fields shared by the packages tests.
+//The fields-template app generator declares app.name and app.port (default 8080) and writes app.txt with both values
+func FieldsTemplatePath() string {
+	_, file, _, _ := runtime.Caller(0)
+	return filepath.Join(filepath.Dir(file), "testing", "templates", "fields-template")
+}